@@ -0,0 +1,258 @@
+package aliyundrive
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type Album struct {
+	AlbumId     string `json:"album_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Owner       string `json:"owner"`
+	FileCount   int    `json:"file_count"`
+	ImageCount  int    `json:"image_count"`
+	VideoCount  int    `json:"video_count"`
+	// CreatedAt 和 UpdatedAt 是毫秒时间戳
+	CreatedAt int64 `json:"created_at"`
+	UpdatedAt int64 `json:"updated_at"`
+	Cover     *struct {
+		List []*Item `json:"list"`
+	} `json:"cover,omitempty"`
+}
+
+func (a *Album) CreatedTime() time.Time {
+	return time.UnixMilli(a.CreatedAt)
+}
+
+func (a *Album) UpdatedTime() time.Time {
+	return time.UnixMilli(a.UpdatedAt)
+}
+
+// AlbumFile 是相册中的文件，DriveId 为空时使用默认的 drive id
+type AlbumFile struct {
+	DriveId string `json:"drive_id"`
+	FileId  string `json:"file_id"`
+}
+
+// resolveAlbumFiles 为 DriveId 为空的文件填充默认的 drive id
+func (c *Drive) resolveAlbumFiles(ctx context.Context, files []*AlbumFile) ([]*AlbumFile, error) {
+	result := make([]*AlbumFile, len(files))
+	for i, file := range files {
+		driveId, err := c.resolveDriveId(ctx, file.DriveId)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = &AlbumFile{DriveId: driveId, FileId: file.FileId}
+	}
+	return result, nil
+}
+
+type CreateAlbumRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type CreateAlbumResponse struct {
+	Album
+}
+
+func (c *Drive) DoCreateAlbumRequest(ctx context.Context, request CreateAlbumRequest) (*CreateAlbumResponse, error) {
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v1/album/create", request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(CreateAlbumResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type ListAlbumsRequest struct {
+	OrderBy        string `json:"order_by,omitempty"`
+	OrderDirection string `json:"order_direction,omitempty"`
+	Limit          int    `json:"limit,omitempty"`
+	NextMarker     string `json:"marker,omitempty"`
+}
+
+type ListAlbumsResponse struct {
+	Items      []*Album `json:"items"`
+	NextMarker string   `json:"next_marker"`
+}
+
+func (c *Drive) DoListAlbumsRequest(ctx context.Context, request ListAlbumsRequest) (*ListAlbumsResponse, error) {
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v1/album/list", request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(ListAlbumsResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetAlbumRequest struct {
+	AlbumId string `json:"album_id"`
+}
+
+type GetAlbumResponse struct {
+	Album
+}
+
+func (c *Drive) DoGetAlbumRequest(ctx context.Context, request GetAlbumRequest) (*GetAlbumResponse, error) {
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v1/album/get", request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(GetAlbumResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type UpdateAlbumRequest struct {
+	AlbumId     string  `json:"album_id"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+type UpdateAlbumResponse struct {
+	Album
+}
+
+// DoUpdateAlbumRequest 只更新非 nil 的字段
+func (c *Drive) DoUpdateAlbumRequest(ctx context.Context, request UpdateAlbumRequest) (*UpdateAlbumResponse, error) {
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v1/album/update", request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(UpdateAlbumResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type DeleteAlbumRequest struct {
+	AlbumId string `json:"album_id"`
+}
+
+type DeleteAlbumResponse struct {
+}
+
+// DoDeleteAlbumRequest 删除相册，相册中的文件不会被删除
+func (c *Drive) DoDeleteAlbumRequest(ctx context.Context, request DeleteAlbumRequest) (*DeleteAlbumResponse, error) {
+	_, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v1/album/delete", request)
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteAlbumResponse{}, nil
+}
+
+type AddAlbumFilesRequest struct {
+	AlbumId       string       `json:"album_id"`
+	DriveFileList []*AlbumFile `json:"-"`
+}
+
+type AddAlbumFilesResponse struct {
+	FileList []*Item `json:"file_list"`
+}
+
+func (c *Drive) DoAddAlbumFilesRequest(ctx context.Context, request AddAlbumFilesRequest) (*AddAlbumFilesResponse, error) {
+	files, err := c.resolveAlbumFiles(ctx, request.DriveFileList)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &struct {
+		DriveFileList []*AlbumFile `json:"drive_file_list"`
+		AddAlbumFilesRequest
+	}{
+		DriveFileList:        files,
+		AddAlbumFilesRequest: request,
+	}
+
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v1/album/add_files", params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(AddAlbumFilesResponse)
+	if len(resp) == 0 {
+		return result, nil
+	}
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type DeleteAlbumFilesRequest struct {
+	AlbumId       string       `json:"album_id"`
+	DriveFileList []*AlbumFile `json:"-"`
+}
+
+type DeleteAlbumFilesResponse struct {
+}
+
+// DoDeleteAlbumFilesRequest 把文件移出相册，文件本身不会被删除
+func (c *Drive) DoDeleteAlbumFilesRequest(ctx context.Context, request DeleteAlbumFilesRequest) (*DeleteAlbumFilesResponse, error) {
+	files, err := c.resolveAlbumFiles(ctx, request.DriveFileList)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &struct {
+		DriveFileList []*AlbumFile `json:"drive_file_list"`
+		DeleteAlbumFilesRequest
+	}{
+		DriveFileList:           files,
+		DeleteAlbumFilesRequest: request,
+	}
+
+	_, err = c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v1/album/delete_files", params)
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteAlbumFilesResponse{}, nil
+}
+
+type ListAlbumFilesRequest struct {
+	AlbumId        string `json:"album_id"`
+	OrderBy        string `json:"order_by,omitempty"`
+	OrderDirection string `json:"order_direction,omitempty"`
+	Limit          int    `json:"limit,omitempty"`
+	NextMarker     string `json:"marker,omitempty"`
+}
+
+type ListAlbumFilesResponse struct {
+	Items      []*Item `json:"items"`
+	NextMarker string  `json:"next_marker"`
+}
+
+func (c *Drive) DoListAlbumFilesRequest(ctx context.Context, request ListAlbumFilesRequest) (*ListAlbumFilesResponse, error) {
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v1/album/list_files", request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(ListAlbumFilesResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -1,22 +1,40 @@
 package aliyundrive
 
 import (
+	"context"
+	"errors"
+	"log"
 	"net/http"
+	"sync"
 )
 
+const DriveKindDefault = "default"
+const DriveKindResource = "resource"
+
+var ErrNoDriveId = errors.New("aliyundrive: no drive id available, use WithDriveId or WithAutoDriveId")
+
 type Drive struct {
-	driveId      string
-	tokenManager TokenManager
-	httpClient   *http.Client
+	driveId       string
+	autoDriveKind string
+	tokenManager  TokenManager
+	httpClient    *http.Client
+	lock          *sync.Mutex
+	sbox          *safeBox
+	userId        string
+	errorLog      *log.Logger
 }
 type optionFunc func(c *Drive)
 
 func New(options ...optionFunc) *Drive {
 	c := new(Drive)
+	c.lock = new(sync.Mutex)
 	c.SetOption(options...)
 	if c.httpClient == nil {
 		c.httpClient = http.DefaultClient
 	}
+	if c.errorLog == nil {
+		c.errorLog = log.Default()
+	}
 	return c
 }
 
@@ -26,6 +44,13 @@ func WithDriveId(driveId string) optionFunc {
 	}
 }
 
+// WithAutoDriveId 在第一次请求时自动查询并缓存 drive id，kind 为 DriveKindDefault 或 DriveKindResource
+func WithAutoDriveId(kind string) optionFunc {
+	return func(c *Drive) {
+		c.autoDriveKind = kind
+	}
+}
+
 func WithHttpClient(httpClient *http.Client) optionFunc {
 	return func(c *Drive) {
 		c.httpClient = httpClient
@@ -38,9 +63,93 @@ func WithTokenManager(tokenManager TokenManager) optionFunc {
 	}
 }
 
+// WithErrorLog 指定记录后台错误的 logger，如保险箱重新锁定失败，默认使用 log 包的标准 logger
+func WithErrorLog(logger *log.Logger) optionFunc {
+	return func(c *Drive) {
+		if logger != nil {
+			c.errorLog = logger
+		}
+	}
+}
+
 func (c *Drive) SetOption(options ...optionFunc) *Drive {
 	for _, setOption := range options {
 		setOption(c)
 	}
 	return c
 }
+
+// Init 查询账号的网盘并设置 drive id，已经设置了 drive id 时不做任何事
+func (c *Drive) Init(ctx context.Context) error {
+	c.lock.Lock()
+	driveId := c.driveId
+	c.lock.Unlock()
+	if driveId != "" {
+		return nil
+	}
+
+	// 查询时不持有锁，避免阻塞其他请求
+	drives, err := c.GetDrives(ctx)
+	if err != nil {
+		return err
+	}
+	driveId = drives.DefaultDriveID
+	if c.autoDriveKind == DriveKindResource || driveId == "" {
+		driveId = drives.ResourceDriveID
+	}
+	if driveId == "" {
+		return ErrNoDriveId
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// 并发的 Init 可能已经设置了 drive id
+	if c.driveId == "" {
+		c.driveId = driveId
+	}
+	return nil
+}
+
+// resolveDriveId 优先使用请求中指定的 drive id，否则使用默认的 drive id
+func (c *Drive) resolveDriveId(ctx context.Context, driveId string) (string, error) {
+	if driveId != "" {
+		return driveId, nil
+	}
+
+	c.lock.Lock()
+	driveId = c.driveId
+	c.lock.Unlock()
+	if driveId != "" {
+		return driveId, nil
+	}
+	if c.autoDriveKind == "" {
+		return "", ErrNoDriveId
+	}
+
+	err := c.Init(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.driveId, nil
+}
+
+// resolveUserId 返回当前用户的 user id，第一次调用时查询并缓存
+func (c *Drive) resolveUserId(ctx context.Context) (string, error) {
+	c.lock.Lock()
+	userId := c.userId
+	c.lock.Unlock()
+	if userId != "" {
+		return userId, nil
+	}
+
+	userInfo, err := c.DoGetUserInfoRequest(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.userId = userInfo.UserID
+	return c.userId, nil
+}
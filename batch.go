@@ -0,0 +1,230 @@
+package aliyundrive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+const BatchLimitMax = 100
+
+var ErrBatchTooLarge = errors.New("aliyundrive: too many requests in one batch")
+var ErrBatchNoResponse = errors.New("aliyundrive: no response for batch sub request")
+
+type BatchSubRequest struct {
+	Id      string            `json:"id"`
+	Method  string            `json:"method"`
+	Url     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    any               `json:"body"`
+}
+
+type BatchRequest struct {
+	Requests   []*BatchSubRequest `json:"requests"`
+	Resource   string             `json:"resource"`
+	ShareToken string             `json:"-"`
+}
+
+type BatchSubResponse struct {
+	Id     string          `json:"id"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// Err 返回子请求的错误，状态码为 2xx 时返回 nil
+func (r *BatchSubResponse) Err() error {
+	if r.Status >= 200 && r.Status < 300 {
+		return nil
+	}
+	result := new(ErrorResponse)
+	err := json.Unmarshal(r.Body, result)
+	if err != nil || (result.Code == "" && result.Message == "") {
+		return &ErrorResponse{Code: strconv.Itoa(r.Status), Message: http.StatusText(r.Status)}
+	}
+	return result
+}
+
+type BatchResponse struct {
+	Responses []*BatchSubResponse `json:"responses"`
+}
+
+// DoBatchRequest 发送最多 BatchLimitMax 个子请求，返回的 Responses 与 Requests 顺序一致，
+// 服务端没有返回结果的子请求对应的元素为 nil
+func (c *Drive) DoBatchRequest(ctx context.Context, request BatchRequest) (*BatchResponse, error) {
+	if len(request.Requests) > BatchLimitMax {
+		return nil, ErrBatchTooLarge
+	}
+	if request.Resource == "" {
+		request.Resource = "file"
+	}
+	for _, sub := range request.Requests {
+		if sub.Method == "" {
+			sub.Method = "POST"
+		}
+		if sub.Headers == nil {
+			sub.Headers = map[string]string{"Content-Type": "application/json"}
+		}
+	}
+
+	header := make(http.Header)
+	if request.ShareToken != "" {
+		header.Set("X-Share-Token", request.ShareToken)
+	}
+	resp, err := c.requestWithCreditAndHeader(ctx, "https://api.aliyundrive.com/v3/batch", request, header)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(BatchResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+
+	byId := make(map[string]*BatchSubResponse, len(result.Responses))
+	for _, sub := range result.Responses {
+		byId[sub.Id] = sub
+	}
+	result.Responses = make([]*BatchSubResponse, len(request.Requests))
+	for i, sub := range request.Requests {
+		result.Responses[i] = byId[sub.Id]
+	}
+	return result, nil
+}
+
+type BatchResult[T any] struct {
+	Response *T
+	Err      error
+}
+
+func (c *Drive) BatchMove(ctx context.Context, requests []MoveRequest) ([]*BatchResult[MoveResponse], error) {
+	subs := make([]*BatchSubRequest, len(requests))
+	for i, request := range requests {
+		driveId, err := c.resolveDriveId(ctx, request.DriveId)
+		if err != nil {
+			return nil, err
+		}
+		toDriveId := request.ToDriveId
+		if toDriveId == "" {
+			toDriveId = driveId
+		}
+		subs[i] = &BatchSubRequest{
+			Url: "/file/move",
+			Body: &struct {
+				DriveId   string `json:"drive_id"`
+				ToDriveId string `json:"to_drive_id"`
+				MoveRequest
+			}{
+				DriveId:     driveId,
+				ToDriveId:   toDriveId,
+				MoveRequest: request,
+			},
+		}
+	}
+	return doBatch[MoveResponse](ctx, c, BatchRequest{Requests: subs})
+}
+
+func (c *Drive) BatchTrash(ctx context.Context, requests []TrashRequest) ([]*BatchResult[TrashResponse], error) {
+	subs := make([]*BatchSubRequest, len(requests))
+	for i, request := range requests {
+		driveId, err := c.resolveDriveId(ctx, request.DriveId)
+		if err != nil {
+			return nil, err
+		}
+		subs[i] = &BatchSubRequest{
+			Url: "/recyclebin/trash",
+			Body: &struct {
+				DriveId string `json:"drive_id"`
+				TrashRequest
+			}{
+				DriveId:      driveId,
+				TrashRequest: request,
+			},
+		}
+	}
+	return doBatch[TrashResponse](ctx, c, BatchRequest{Requests: subs})
+}
+
+func (c *Drive) BatchDelete(ctx context.Context, requests []DeleteRequest) ([]*BatchResult[DeleteResponse], error) {
+	subs := make([]*BatchSubRequest, len(requests))
+	for i, request := range requests {
+		driveId, err := c.resolveDriveId(ctx, request.DriveId)
+		if err != nil {
+			return nil, err
+		}
+		subs[i] = &BatchSubRequest{
+			Url: "/file/delete",
+			Body: &struct {
+				DriveId string `json:"drive_id"`
+				DeleteRequest
+			}{
+				DriveId:       driveId,
+				DeleteRequest: request,
+			},
+		}
+	}
+	return doBatch[DeleteResponse](ctx, c, BatchRequest{Requests: subs})
+}
+
+func (c *Drive) BatchRestore(ctx context.Context, requests []RestoreRequest) ([]*BatchResult[RestoreResponse], error) {
+	subs := make([]*BatchSubRequest, len(requests))
+	for i, request := range requests {
+		driveId, err := c.resolveDriveId(ctx, request.DriveId)
+		if err != nil {
+			return nil, err
+		}
+		subs[i] = &BatchSubRequest{
+			Url: "/recyclebin/restore",
+			Body: &struct {
+				DriveId string `json:"drive_id"`
+				RestoreRequest
+			}{
+				DriveId:        driveId,
+				RestoreRequest: request,
+			},
+		}
+	}
+	return doBatch[RestoreResponse](ctx, c, BatchRequest{Requests: subs})
+}
+
+// doBatch 按 BatchLimitMax 分组发送子请求，并按顺序返回每个子请求的结果。
+// 某一组请求失败时，返回已经完成的结果和错误。
+func doBatch[T any](ctx context.Context, c *Drive, request BatchRequest) ([]*BatchResult[T], error) {
+	subs := request.Requests
+	results := make([]*BatchResult[T], 0, len(subs))
+	for start := 0; start < len(subs); start += BatchLimitMax {
+		end := start + BatchLimitMax
+		if end > len(subs) {
+			end = len(subs)
+		}
+
+		chunk := subs[start:end]
+		for i, sub := range chunk {
+			sub.Id = strconv.Itoa(start + i)
+		}
+		request.Requests = chunk
+		resp, err := c.DoBatchRequest(ctx, request)
+		if err != nil {
+			return results, err
+		}
+
+		for _, sub := range resp.Responses {
+			result := new(BatchResult[T])
+			switch {
+			case sub == nil:
+				result.Err = ErrBatchNoResponse
+			case sub.Err() != nil:
+				result.Err = sub.Err()
+			default:
+				result.Response = new(T)
+				if len(sub.Body) > 0 {
+					result.Err = json.Unmarshal(sub.Body, result.Response)
+				}
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,199 @@
+package aliyundrive
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// CopyToRequest 中的 CopyRequest.ToDriveId 为目标网盘，AutoRename 不生效
+type CopyToRequest struct {
+	CopyRequest
+	// AllowTransfer 为 true 时秒传失败的文件会下载后重新上传，否则返回 *RapidUploadError
+	AllowTransfer bool
+}
+
+type CopyToResponse struct {
+	FileId string
+}
+
+// RapidUploadError 表示文件无法通过内容哈希秒传到目标网盘
+type RapidUploadError struct {
+	FileId string
+	Name   string
+}
+
+func (e *RapidUploadError) Error() string {
+	return fmt.Sprintf("aliyundrive: rapid upload of %v (%v) failed", e.Name, e.FileId)
+}
+
+func IsRapidUploadError(err error) bool {
+	_, ok := err.(*RapidUploadError)
+	return ok
+}
+
+// CopyTo 把文件或文件夹复制到同一账号的另一个网盘中。文件通过内容哈希秒传，不会重新传输数据，
+// 秒传失败时返回 *RapidUploadError，设置 AllowTransfer 时才会下载后重新上传。
+func (c *Drive) CopyTo(ctx context.Context, request CopyToRequest) (*CopyToResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+	toDriveId := request.ToDriveId
+	if toDriveId == "" {
+		toDriveId = driveId
+	}
+
+	getResp, err := c.DoGetRequest(ctx, GetRequest{DriveId: driveId, FileId: request.FileId})
+	if err != nil {
+		return nil, err
+	}
+	name := request.NewName
+	if name == "" {
+		name = getResp.Name
+	}
+	copier := &driveCopier{
+		c:             c,
+		driveId:       driveId,
+		toDriveId:     toDriveId,
+		allowTransfer: request.AllowTransfer,
+	}
+	fileId, err := copier.copyItem(ctx, &getResp.Item, request.ToParentFileId, name)
+	if err != nil {
+		return nil, err
+	}
+	return &CopyToResponse{FileId: fileId}, nil
+}
+
+type driveCopier struct {
+	c             *Drive
+	driveId       string
+	toDriveId     string
+	allowTransfer bool
+}
+
+func (p *driveCopier) copyItem(ctx context.Context, item *Item, parentFileId string, name string) (string, error) {
+	if item.Type != "folder" {
+		return p.copyFile(ctx, item, parentFileId, name)
+	}
+
+	createFolderResp, err := p.c.DoCreateFolderRequest(ctx, CreateFolderRequest{
+		DriveId:      p.toDriveId,
+		Name:         name,
+		ParentFileId: parentFileId,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	pager := p.c.NewListPager(ListRequest{
+		DriveId:      p.driveId,
+		ParentFileId: item.FileId,
+		Limit:        LimitMax,
+	})
+	err = pager.ForEach(ctx, func(child *Item) error {
+		_, err := p.copyItem(ctx, child, createFolderResp.FileId, child.Name)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return createFolderResp.FileId, nil
+}
+
+func (p *driveCopier) copyFile(ctx context.Context, item *Item, parentFileId string, name string) (string, error) {
+	accessToken, err := p.c.tokenManager.AccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// 秒传需要提供文件中一段数据作为证明
+	proofCode := ""
+	if item.Size > 0 {
+		start := GetProofStart(accessToken, item.Size)
+		end := start + 8
+		if end > item.Size {
+			end = item.Size
+		}
+		body, err := p.openFileRange(ctx, item.FileId, fmt.Sprintf("bytes=%v-%v", start, end-1))
+		if err != nil {
+			return "", err
+		}
+		proof, err := io.ReadAll(io.LimitReader(body, int64(end-start)))
+		body.Close()
+		if err != nil {
+			return "", err
+		}
+		proofCode = base64.StdEncoding.EncodeToString(proof)
+	}
+
+	rapidResp, err := p.c.DoRapidCreateFileRequest(ctx, RapidCreateFileRequest{
+		DriveId:      p.toDriveId,
+		Name:         name,
+		ParentFileId: parentFileId,
+		Size:         item.Size,
+		ChunkSize:    DefaultChunkSize,
+		ContentHash:  item.ContentHash,
+		ProofCode:    proofCode,
+		AccessToken:  accessToken,
+	})
+	if err != nil {
+		return "", err
+	}
+	if rapidResp.RapidUpload {
+		return rapidResp.FileId, nil
+	}
+	if !p.allowTransfer {
+		return "", &RapidUploadError{FileId: item.FileId, Name: item.Name}
+	}
+
+	// 秒传失败，下载后重新上传
+	body, err := p.openFileRange(ctx, item.FileId, "")
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	err = p.c.uploadParts(ctx, rapidResp.PartInfoList, body, item.Size, DefaultChunkSize)
+	if err != nil {
+		return "", err
+	}
+	completeResp, err := p.c.DoCompleteUploadFileRequest(ctx, CompleteUploadFileRequest{
+		DriveId:  p.toDriveId,
+		FileId:   rapidResp.FileId,
+		UploadId: rapidResp.UploadId,
+	})
+	if err != nil {
+		return "", err
+	}
+	return completeResp.FileId, nil
+}
+
+func (p *driveCopier) openFileRange(ctx context.Context, fileId string, byteRange string) (io.ReadCloser, error) {
+	getDownloadUrlResp, err := p.c.DoGetDownloadUrlRequest(ctx, GetDownloadUrlRequest{DriveId: p.driveId, FileId: fileId})
+	if err != nil {
+		return nil, err
+	}
+	header := make(http.Header)
+	if byteRange != "" {
+		header.Set("Range", byteRange)
+	}
+	downloadResp, err := p.c.DoDownloadFileRequest(ctx, DownloadFileRequest{
+		Url:    getDownloadUrlResp.Url,
+		Header: header,
+	})
+	if err != nil {
+		return nil, err
+	}
+	status := http.StatusOK
+	if byteRange != "" {
+		status = http.StatusPartialContent
+	}
+	if downloadResp.StatusCode != status {
+		downloadResp.Reader.Close()
+		return nil, &ErrorResponse{Code: strconv.Itoa(downloadResp.StatusCode), Message: http.StatusText(downloadResp.StatusCode)}
+	}
+	return downloadResp.Reader, nil
+}
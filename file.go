@@ -7,6 +7,7 @@ import (
 	"io"
 	"math/big"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -23,6 +24,8 @@ const OrderByName = "name"
 const OrderByUpdatedAt = "updated_at"
 const OrderByCreatedAt = "created_at"
 
+const starredCustomIndexKey = "starred_yes"
+
 func GetProofStart(accessToken string, size uint64) uint64 {
 	hash := md5.Sum([]byte(accessToken))
 	bigInt := new(big.Int).SetBytes(hash[:8])
@@ -59,6 +62,11 @@ type Item struct {
 	DownloadUrl     string    `json:"download_url"`
 	UploadId        string    `json:"upload_id"`
 	Labels          []string  `json:"labels"`
+	Description     string    `json:"description"`
+
+	ImageMediaMetadata   *ImageMediaMetadata   `json:"image_media_metadata,omitempty"`
+	VideoMediaMetadata   *VideoMediaMetadata   `json:"video_media_metadata,omitempty"`
+	VideoPreviewMetadata *VideoPreviewMetadata `json:"video_preview_metadata,omitempty"`
 }
 
 type ItemQuery []*Item
@@ -78,20 +86,8 @@ type GetPersonalInfoRequest struct {
 }
 
 type GetPersonalInfoResponse struct {
-	PersonalRightsInfo *struct {
-		Name       string `json:"name"`
-		SpuId      string `json:"spu_id"`
-		IsExpires  bool   `json:"is_expires"`
-		Privileges []*struct {
-			FeatureId     string `json:"feature_id"`
-			FeatureAttrId string `json:"feature_attr_id"`
-			Quota         int    `json:"quota"`
-		} `json:"privileges"`
-	} `json:"personal_rights_info"`
-	PersonalSpaceInfo *struct {
-		TotalSize uint64 `json:"total_size"`
-		UsedSize  uint64 `json:"used_size"`
-	} `json:"personal_space_info"`
+	PersonalRightsInfo *PersonalRightsInfo `json:"personal_rights_info"`
+	PersonalSpaceInfo  *PersonalSpaceInfo  `json:"personal_space_info"`
 }
 
 func (c *Drive) DoGetPersonalInfoRequest(ctx context.Context, request GetPersonalInfoRequest) (*GetPersonalInfoResponse, error) {
@@ -109,6 +105,7 @@ func (c *Drive) DoGetPersonalInfoRequest(ctx context.Context, request GetPersona
 }
 
 type ListRequest struct {
+	DriveId        string `json:"-"`
 	ParentFileId   string `json:"parent_file_id,omitempty"`
 	OrderBy        string `json:"order_by,omitempty"`
 	OrderDirection string `json:"order_direction,omitempty"`
@@ -121,12 +118,17 @@ type ListResponse struct {
 }
 
 func (c *Drive) DoListRequest(ctx context.Context, request ListRequest) (*ListResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId string `json:"drive_id"`
 		Fields  string `json:"fields"`
 		ListRequest
 	}{
-		DriveId:     c.driveId,
+		DriveId:     driveId,
 		Fields:      "*",
 		ListRequest: request,
 	}
@@ -144,7 +146,9 @@ func (c *Drive) DoListRequest(ctx context.Context, request ListRequest) (*ListRe
 }
 
 type SearchRequest struct {
+	DriveId        string `json:"-"`
 	Name           string `json:"-"`
+	Query          string `json:"-"`
 	OrderBy        string `json:"order_by,omitempty"`
 	OrderDirection string `json:"order_direction,omitempty"`
 	Limit          int    `json:"limit,omitempty"`
@@ -158,15 +162,26 @@ type SearchResponse struct {
 
 func (c *Drive) DoSearchRequest(ctx context.Context, request SearchRequest) (*SearchResponse, error) {
 
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId string `json:"drive_id"`
 		Query   string `json:"query"`
+		Fields  string `json:"fields"`
 		SearchRequest
 	}{
-		DriveId:       c.driveId,
+		DriveId:       driveId,
+		Fields:        "*",
 		SearchRequest: request,
 	}
-	params.Query = `name match "` + params.Name + `"`
+	// Query 为空时按文件名搜索
+	params.Query = request.Query
+	if params.Query == "" {
+		params.Query = QueryNameMatch(request.Name).String()
+	}
 
 	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v3/file/search", params)
 	if err != nil {
@@ -182,7 +197,8 @@ func (c *Drive) DoSearchRequest(ctx context.Context, request SearchRequest) (*Se
 }
 
 type GetRequest struct {
-	FileId string `json:"file_id"`
+	DriveId string `json:"-"`
+	FileId  string `json:"file_id"`
 }
 
 type GetResponse struct {
@@ -191,11 +207,18 @@ type GetResponse struct {
 
 func (c *Drive) DoGetRequest(ctx context.Context, request GetRequest) (*GetResponse, error) {
 
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId string `json:"drive_id"`
+		Fields  string `json:"fields"`
 		GetRequest
 	}{
-		DriveId:    c.driveId,
+		DriveId:    driveId,
+		Fields:     "*",
 		GetRequest: request,
 	}
 	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/file/get", params)
@@ -212,7 +235,8 @@ func (c *Drive) DoGetRequest(ctx context.Context, request GetRequest) (*GetRespo
 }
 
 type GetDownloadUrlRequest struct {
-	FileId string `json:"file_id"`
+	DriveId string `json:"-"`
+	FileId  string `json:"file_id"`
 }
 
 type GetDownloadUrlResponse struct {
@@ -228,11 +252,16 @@ type GetDownloadUrlResponse struct {
 
 func (c *Drive) DoGetDownloadUrlRequest(ctx context.Context, request GetDownloadUrlRequest) (*GetDownloadUrlResponse, error) {
 
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId string `json:"drive_id"`
 		GetDownloadUrlRequest
 	}{
-		DriveId:               c.driveId,
+		DriveId:               driveId,
 		GetDownloadUrlRequest: request,
 	}
 	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/file/get_download_url", params)
@@ -249,7 +278,8 @@ func (c *Drive) DoGetDownloadUrlRequest(ctx context.Context, request GetDownload
 }
 
 type GetFolderSizeInfoRequest struct {
-	FileId string `json:"file_id"`
+	DriveId string `json:"-"`
+	FileId  string `json:"file_id"`
 }
 
 type GetFolderSizeInfoResponse struct {
@@ -260,11 +290,16 @@ type GetFolderSizeInfoResponse struct {
 
 func (c *Drive) DoGetFolderSizeInfoRequest(ctx context.Context, request GetFolderSizeInfoRequest) (*GetFolderSizeInfoResponse, error) {
 
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId string `json:"drive_id"`
 		GetFolderSizeInfoRequest
 	}{
-		DriveId:                  c.driveId,
+		DriveId:                  driveId,
 		GetFolderSizeInfoRequest: request,
 	}
 	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v1/file/get_folder_size_info", params)
@@ -281,6 +316,7 @@ func (c *Drive) DoGetFolderSizeInfoRequest(ctx context.Context, request GetFolde
 }
 
 type CreateFolderRequest struct {
+	DriveId      string `json:"-"`
 	Name         string `json:"name"`
 	ParentFileId string `json:"parent_file_id"`
 }
@@ -294,13 +330,18 @@ type CreateFolderResponse struct {
 }
 
 func (c *Drive) DoCreateFolderRequest(ctx context.Context, request CreateFolderRequest) (*CreateFolderResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId       string `json:"drive_id"`
 		CheckNameMode string `json:"check_name_mode"`
 		Type          string `json:"type"`
 		CreateFolderRequest
 	}{
-		DriveId:             c.driveId,
+		DriveId:             driveId,
 		CheckNameMode:       "refuse",
 		Type:                "folder",
 		CreateFolderRequest: request,
@@ -320,6 +361,7 @@ func (c *Drive) DoCreateFolderRequest(ctx context.Context, request CreateFolderR
 }
 
 type CreateFileRequest struct {
+	DriveId      string `json:"-"`
 	Name         string `json:"name"`
 	ParentFileId string `json:"parent_file_id"`
 	Size         uint64 `json:"size"`
@@ -327,23 +369,30 @@ type CreateFileRequest struct {
 	ChunkSize    uint64 `json:"-"`
 }
 
+type PartInfo struct {
+	PartNumber        int    `json:"part_number"`
+	ContentType       string `json:"content_type"`
+	InternalUploadUrl string `json:"internal_upload_url"`
+	UploadUrl         string `json:"upload_url"`
+}
+
 type CreateFileResponse struct {
-	FileId       string `json:"file_id"`
-	FileName     string `json:"file_name"`
-	ParentFileId string `json:"parent_file_id"`
-	RapidUpload  bool   `json:"rapid_upload"`
-	Type         string `json:"type"`
-	EncryptMode  string `json:"encrypt_mode"`
-	UploadId     string `json:"upload_id"`
-	PartInfoList []*struct {
-		PartNumber        int    `json:"part_number"`
-		ContentType       string `json:"content_type"`
-		InternalUploadUrl string `json:"internal_upload_url"`
-		UploadUrl         string `json:"upload_url"`
-	} `json:"part_info_list"`
+	FileId       string      `json:"file_id"`
+	FileName     string      `json:"file_name"`
+	ParentFileId string      `json:"parent_file_id"`
+	RapidUpload  bool        `json:"rapid_upload"`
+	Type         string      `json:"type"`
+	EncryptMode  string      `json:"encrypt_mode"`
+	UploadId     string      `json:"upload_id"`
+	PartInfoList []*PartInfo `json:"part_info_list"`
 }
 
 func (c *Drive) DoCreateFileRequest(ctx context.Context, request CreateFileRequest) (*CreateFileResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId       string `json:"drive_id"`
 		DeviceName    string `json:"device_name"`
@@ -353,7 +402,7 @@ func (c *Drive) DoCreateFileRequest(ctx context.Context, request CreateFileReque
 		PartInfoList  Array  `json:"part_info_list"`
 		CreateFileRequest
 	}{
-		DriveId:           c.driveId,
+		DriveId:           driveId,
 		CheckNameMode:     "auto_rename",
 		CreateScene:       "file_upload",
 		Type:              "file",
@@ -394,7 +443,9 @@ type DownloadFileRequest struct {
 }
 
 type DownloadFileResponse struct {
-	Reader io.ReadCloser
+	StatusCode int
+	Header     http.Header
+	Reader     io.ReadCloser
 }
 
 func (c *Drive) DoDownloadFileRequest(ctx context.Context, request DownloadFileRequest) (*DownloadFileResponse, error) {
@@ -413,7 +464,9 @@ func (c *Drive) DoDownloadFileRequest(ctx context.Context, request DownloadFileR
 		return nil, err
 	}
 	return &DownloadFileResponse{
-		Reader: resp.Body,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Reader:     resp.Body,
 	}, nil
 }
 
@@ -442,10 +495,15 @@ func (c *Drive) DoUploadFileRequest(ctx context.Context, request UploadFileReque
 	if err != nil {
 		return nil, err
 	}
+	// 上传地址过期或无权限时 oss 返回 403 等错误状态
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &ErrorResponse{Code: strconv.Itoa(resp.StatusCode), Message: http.StatusText(resp.StatusCode)}
+	}
 	return &UploadFileResponse{}, nil
 }
 
 type CompleteUploadFileRequest struct {
+	DriveId  string `json:"-"`
 	FileId   string `json:"file_id"`
 	UploadId string `json:"upload_id"`
 }
@@ -455,11 +513,16 @@ type CompleteUploadFileResponse struct {
 }
 
 func (c *Drive) DoCompleteUploadFileRequest(ctx context.Context, request CompleteUploadFileRequest) (*CompleteUploadFileResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId string `json:"drive_id"`
 		CompleteUploadFileRequest
 	}{
-		DriveId:                   c.driveId,
+		DriveId:                   driveId,
 		CompleteUploadFileRequest: request,
 	}
 
@@ -477,6 +540,7 @@ func (c *Drive) DoCompleteUploadFileRequest(ctx context.Context, request Complet
 }
 
 type RapidCreateFileRequest struct {
+	DriveId      string `json:"-"`
 	Name         string `json:"name"`
 	ParentFileId string `json:"parent_file_id"`
 	Size         uint64 `json:"size"`
@@ -487,16 +551,22 @@ type RapidCreateFileRequest struct {
 }
 
 type RapidCreateFileResponse struct {
-	FileId       string `json:"file_id"`
-	FileName     string `json:"file_name"`
-	ParentFileId string `json:"parent_file_id"`
-	RapidUpload  bool   `json:"rapid_upload"`
-	Type         string `json:"type"`
-	EncryptMode  string `json:"encrypt_mode"`
-	UploadId     string `json:"upload_id"`
+	FileId       string      `json:"file_id"`
+	FileName     string      `json:"file_name"`
+	ParentFileId string      `json:"parent_file_id"`
+	RapidUpload  bool        `json:"rapid_upload"`
+	Type         string      `json:"type"`
+	EncryptMode  string      `json:"encrypt_mode"`
+	UploadId     string      `json:"upload_id"`
+	PartInfoList []*PartInfo `json:"part_info_list"`
 }
 
 func (c *Drive) DoRapidCreateFileRequest(ctx context.Context, request RapidCreateFileRequest) (*RapidCreateFileResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId         string `json:"drive_id"`
 		DeviceName      string `json:"device_name"`
@@ -508,7 +578,7 @@ func (c *Drive) DoRapidCreateFileRequest(ctx context.Context, request RapidCreat
 		PartInfoList    Array  `json:"part_info_list"`
 		RapidCreateFileRequest
 	}{
-		DriveId:                c.driveId,
+		DriveId:                driveId,
 		CheckNameMode:          "auto_rename",
 		CreateScene:            "file_upload",
 		ContentHashName:        "sha1",
@@ -551,8 +621,9 @@ func (c *Drive) DoRapidCreateFileRequest(ctx context.Context, request RapidCreat
 }
 
 type RenameRequest struct {
-	FileId string `json:"file_id"`
-	Name   string `json:"name"`
+	DriveId string `json:"-"`
+	FileId  string `json:"file_id"`
+	Name    string `json:"name"`
 }
 
 type RenameResponse struct {
@@ -560,14 +631,62 @@ type RenameResponse struct {
 }
 
 func (c *Drive) DoRenameRequest(ctx context.Context, request RenameRequest) (*RenameResponse, error) {
+	resp, err := c.DoUpdateFileRequest(ctx, UpdateFileRequest{
+		DriveId: request.DriveId,
+		FileId:  request.FileId,
+		Name:    request.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RenameResponse{Item: resp.Item}, nil
+}
+
+// UpdateFileRequest 中为零值的字段不会被修改，Labels 指向空切片时清空标签
+type UpdateFileRequest struct {
+	DriveId         string     `json:"-"`
+	FileId          string     `json:"file_id"`
+	Name            string     `json:"name,omitempty"`
+	Starred         *bool      `json:"starred,omitempty"`
+	Hidden          *bool      `json:"hidden,omitempty"`
+	Description     *string    `json:"description,omitempty"`
+	Labels          *[]string  `json:"labels,omitempty"`
+	UserMeta        *string    `json:"user_meta,omitempty"`
+	LocalCreatedAt  *time.Time `json:"local_created_at,omitempty"`
+	LocalModifiedAt *time.Time `json:"local_modified_at,omitempty"`
+}
+
+type UpdateFileResponse struct {
+	Item
+}
+
+func (c *Drive) DoUpdateFileRequest(ctx context.Context, request UpdateFileRequest) (*UpdateFileResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
-		DriveId       string `json:"drive_id"`
-		CheckNameMode string `json:"check_name_mode"`
-		RenameRequest
+		DriveId        string  `json:"drive_id"`
+		CheckNameMode  string  `json:"check_name_mode"`
+		CustomIndexKey *string `json:"custom_index_key,omitempty"`
+		UpdateFileRequest
 	}{
-		DriveId:       c.driveId,
-		CheckNameMode: "refuse",
-		RenameRequest: request,
+		DriveId:           driveId,
+		CheckNameMode:     "refuse",
+		UpdateFileRequest: request,
+	}
+	// 收藏列表通过 custom_index_key 索引
+	if request.Starred != nil {
+		customIndexKey := ""
+		if *request.Starred {
+			customIndexKey = starredCustomIndexKey
+		}
+		params.CustomIndexKey = &customIndexKey
+	}
+	// nil 切片会编码为 null，清空标签时需要发送 []
+	if request.Labels != nil && *request.Labels == nil {
+		params.Labels = &[]string{}
 	}
 
 	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v3/file/update", params)
@@ -575,7 +694,53 @@ func (c *Drive) DoRenameRequest(ctx context.Context, request RenameRequest) (*Re
 		return nil, err
 	}
 
-	result := new(RenameResponse)
+	result := new(UpdateFileResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type ListStarredRequest struct {
+	DriveId        string `json:"-"`
+	OrderBy        string `json:"order_by,omitempty"`
+	OrderDirection string `json:"order_direction,omitempty"`
+	Limit          int    `json:"limit,omitempty"`
+	NextMarker     string `json:"marker,omitempty"`
+}
+
+type ListStarredResponse struct {
+	Items      []*Item `json:"items"`
+	NextMarker string  `json:"next_marker"`
+}
+
+func (c *Drive) DoListStarredRequest(ctx context.Context, request ListStarredRequest) (*ListStarredResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &struct {
+		DriveId        string `json:"drive_id"`
+		CustomIndexKey string `json:"custom_index_key"`
+		ParentFileId   string `json:"parent_file_id"`
+		Fields         string `json:"fields"`
+		ListStarredRequest
+	}{
+		DriveId:            driveId,
+		CustomIndexKey:     starredCustomIndexKey,
+		ParentFileId:       RootFileId,
+		Fields:             "*",
+		ListStarredRequest: request,
+	}
+
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/file/list_by_custom_index_key", params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(ListStarredResponse)
 	err = json.Unmarshal(resp, result)
 	if err != nil {
 		return nil, err
@@ -584,6 +749,8 @@ func (c *Drive) DoRenameRequest(ctx context.Context, request RenameRequest) (*Re
 }
 
 type MoveRequest struct {
+	DriveId        string `json:"-"`
+	ToDriveId      string `json:"-"`
 	FileId         string `json:"file_id"`
 	ToParentFileId string `json:"to_parent_file_id"`
 }
@@ -593,13 +760,22 @@ type MoveResponse struct {
 }
 
 func (c *Drive) DoMoveRequest(ctx context.Context, request MoveRequest) (*MoveResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+	toDriveId := request.ToDriveId
+	if toDriveId == "" {
+		toDriveId = driveId
+	}
+
 	params := &struct {
 		DriveId   string `json:"drive_id"`
 		ToDriveId string `json:"to_drive_id"`
 		MoveRequest
 	}{
-		DriveId:     c.driveId,
-		ToDriveId:   c.driveId,
+		DriveId:     driveId,
+		ToDriveId:   toDriveId,
 		MoveRequest: request,
 	}
 
@@ -616,8 +792,57 @@ func (c *Drive) DoMoveRequest(ctx context.Context, request MoveRequest) (*MoveRe
 	return result, nil
 }
 
+type CopyRequest struct {
+	DriveId        string `json:"-"`
+	ToDriveId      string `json:"-"`
+	FileId         string `json:"file_id"`
+	ToParentFileId string `json:"to_parent_file_id"`
+	NewName        string `json:"new_name,omitempty"`
+	AutoRename     bool   `json:"auto_rename"`
+}
+
+type CopyResponse struct {
+	FileId      string `json:"file_id"`
+	DriveId     string `json:"drive_id"`
+	AsyncTaskId string `json:"async_task_id"`
+}
+
+func (c *Drive) DoCopyRequest(ctx context.Context, request CopyRequest) (*CopyResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+	toDriveId := request.ToDriveId
+	if toDriveId == "" {
+		toDriveId = driveId
+	}
+
+	params := &struct {
+		DriveId   string `json:"drive_id"`
+		ToDriveId string `json:"to_drive_id"`
+		CopyRequest
+	}{
+		DriveId:     driveId,
+		ToDriveId:   toDriveId,
+		CopyRequest: request,
+	}
+
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/file/copy", params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(CopyResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 type TrashRequest struct {
-	FileId string `json:"file_id"`
+	DriveId string `json:"-"`
+	FileId  string `json:"file_id"`
 }
 
 type TrashResponse struct {
@@ -626,11 +851,16 @@ type TrashResponse struct {
 }
 
 func (c *Drive) DoTrashRequest(ctx context.Context, request TrashRequest) (*TrashResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId string `json:"drive_id"`
 		TrashRequest
 	}{
-		DriveId:      c.driveId,
+		DriveId:      driveId,
 		TrashRequest: request,
 	}
 
@@ -647,7 +877,9 @@ func (c *Drive) DoTrashRequest(ctx context.Context, request TrashRequest) (*Tras
 	return result, nil
 }
 
-type ClearTrashRequest struct{}
+type ClearTrashRequest struct {
+	DriveId string `json:"-"`
+}
 
 type ClearTrashResponse struct {
 	AsyncTaskId string `json:"async_task_id"`
@@ -655,11 +887,16 @@ type ClearTrashResponse struct {
 }
 
 func (c *Drive) DoClearTrashRequest(ctx context.Context, request ClearTrashRequest) (*ClearTrashResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId string `json:"drive_id"`
 		ClearTrashRequest
 	}{
-		DriveId:           c.driveId,
+		DriveId:           driveId,
 		ClearTrashRequest: request,
 	}
 
@@ -677,6 +914,7 @@ func (c *Drive) DoClearTrashRequest(ctx context.Context, request ClearTrashReque
 }
 
 type ListTrashRequest struct {
+	DriveId        string `json:"-"`
 	OrderBy        string `json:"order_by,omitempty"`
 	OrderDirection string `json:"order_direction,omitempty"`
 	Limit          int    `json:"limit,omitempty"`
@@ -689,11 +927,16 @@ type ListTrashResponse struct {
 }
 
 func (c *Drive) DoListTrashRequest(ctx context.Context, request ListTrashRequest) (*ListTrashResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId string `json:"drive_id"`
 		ListTrashRequest
 	}{
-		DriveId:          c.driveId,
+		DriveId:          driveId,
 		ListTrashRequest: request,
 	}
 
@@ -711,18 +954,24 @@ func (c *Drive) DoListTrashRequest(ctx context.Context, request ListTrashRequest
 }
 
 type RestoreRequest struct {
-	FileId string `json:"file_id"`
+	DriveId string `json:"-"`
+	FileId  string `json:"file_id"`
 }
 
 type RestoreResponse struct {
 }
 
 func (c *Drive) DoRestoreRequest(ctx context.Context, request RestoreRequest) (*RestoreResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId string `json:"drive_id"`
 		RestoreRequest
 	}{
-		DriveId:        c.driveId,
+		DriveId:        driveId,
 		RestoreRequest: request,
 	}
 
@@ -748,38 +997,41 @@ func (c *Drive) DoRestoreRequest(ctx context.Context, request RestoreRequest) (*
 }
 
 type DeleteRequest struct {
-	FileId string `json:"file_id"`
+	DriveId string `json:"-"`
+	FileId  string `json:"file_id"`
 }
 
 type DeleteResponse struct {
+	AsyncTaskId string `json:"async_task_id"`
+	FileId      string `json:"file_id"`
 }
 
 func (c *Drive) DoDeleteRequest(ctx context.Context, request DeleteRequest) (*DeleteResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &struct {
 		DriveId string `json:"drive_id"`
 		DeleteRequest
 	}{
-		DriveId:       c.driveId,
+		DriveId:       driveId,
 		DeleteRequest: request,
 	}
 
-	accessToken, err := c.tokenManager.AccessToken(ctx)
-	if err != nil {
-		return nil, err
-	}
-	httpRequest, err := c.toRequest(ctx, "https://api.aliyundrive.com/v3/file/delete", params)
-	if err != nil {
-		return nil, err
-	}
-	httpRequest.Header.Set("Authorization", "Bearer "+accessToken)
-	resp, err := c.httpClient.Do(httpRequest)
+	respData, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v3/file/delete", params)
 	if err != nil {
 		return nil, err
 	}
-	_, err = io.Copy(io.Discard, resp.Body)
-	resp.Body.Close()
-	if err != nil {
-		return nil, err
+
+	// 删除文件时没有响应体，删除文件夹时返回异步任务
+	result := new(DeleteResponse)
+	if len(respData) > 0 {
+		err = json.Unmarshal(respData, result)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return &DeleteResponse{}, nil
+	return result, nil
 }
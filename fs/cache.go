@@ -0,0 +1,80 @@
+package fs
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xbugio/aliyundrive-go-sdk"
+)
+
+// DefaultCacheTTL 是推荐的路径缓存有效期，用于 WithCache
+const DefaultCacheTTL = time.Minute
+
+type cacheEntry struct {
+	item   *aliyundrive.Item
+	expire time.Time
+}
+
+// pathCache 缓存路径到文件的映射，避免每次打开文件都从根目录逐级列出。
+// 通过本文件系统的写操作会使相关路径失效，其他客户端的修改在 ttl 后生效。
+// nil 表示不使用缓存。
+type pathCache struct {
+	ttl     time.Duration
+	lock    *sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+func newPathCache(ttl time.Duration) *pathCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &pathCache{
+		ttl:     ttl,
+		lock:    new(sync.Mutex),
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+func (c *pathCache) get(p string) (*aliyundrive.Item, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[p]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expire) {
+		delete(c.entries, p)
+		return nil, false
+	}
+	return entry.item, true
+}
+
+func (c *pathCache) put(p string, item *aliyundrive.Item) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries[p] = &cacheEntry{item: item, expire: time.Now().Add(c.ttl)}
+}
+
+// invalidate 删除 p 以及 p 下所有路径的缓存
+func (c *pathCache) invalidate(p string) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	prefix := strings.TrimSuffix(p, "/") + "/"
+	for k := range c.entries {
+		if k == p || strings.HasPrefix(k, prefix) {
+			delete(c.entries, k)
+		}
+	}
+}
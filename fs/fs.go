@@ -7,26 +7,75 @@ import (
 	"io/fs"
 	"net/http"
 	"path"
+	"strconv"
 	"time"
 
 	"github.com/xbugio/aliyundrive-go-sdk"
 )
 
 type Fs struct {
-	c    *aliyundrive.Drive
-	root string
+	c         *aliyundrive.Drive
+	src       source
+	ctx       context.Context
+	root      string
+	permanent bool
+	cache     *pathCache
 }
 
-func New(c *aliyundrive.Drive, root string) fs.FS {
-	return &Fs{c: c, root: root}
+type optionFunc func(f *Fs)
+
+// ContextFS 是可以绑定 context 的文件系统
+type ContextFS interface {
+	fs.FS
+	WithContext(ctx context.Context) fs.FS
+}
+
+func New(c *aliyundrive.Drive, root string, options ...optionFunc) fs.FS {
+	f := &Fs{c: c, src: &driveSource{c: c}, ctx: context.Background(), root: root}
+	for _, setOption := range options {
+		setOption(f)
+	}
+	return f
+}
+
+// WithPermanentRemove 使 Remove/RemoveAll 直接删除文件而不是移入回收站
+func WithPermanentRemove(permanent bool) optionFunc {
+	return func(f *Fs) {
+		f.permanent = permanent
+	}
+}
+
+// WithCache 缓存路径查找的结果，ttl 不大于 0 时不使用缓存，默认不使用。
+// 其他客户端的修改最多在 ttl 之后才能看到，适合 WebDAV 等频繁按路径访问的场景
+func WithCache(ttl time.Duration) optionFunc {
+	return func(f *Fs) {
+		f.cache = newPathCache(ttl)
+	}
 }
 
 func (f *Fs) Open(name string) (fs.File, error) {
-	return f.open(context.Background(), name)
+	return f.OpenContext(f.ctx, name)
 }
 
 func (f *Fs) ReadDir(name string) ([]fs.DirEntry, error) {
-	file, err := f.open(context.Background(), name)
+	return f.ReadDirContext(f.ctx, name)
+}
+
+func (f *Fs) Stat(name string) (fs.FileInfo, error) {
+	return f.StatContext(f.ctx, name)
+}
+
+// OpenContext 打开文件，返回的文件在后续的 Read、Seek、ReadDir 中继续使用 ctx
+func (f *Fs) OpenContext(ctx context.Context, name string) (fs.File, error) {
+	file, err := f.open(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (f *Fs) ReadDirContext(ctx context.Context, name string) ([]fs.DirEntry, error) {
+	file, err := f.open(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -34,8 +83,8 @@ func (f *Fs) ReadDir(name string) ([]fs.DirEntry, error) {
 	return file.ReadDir(-1)
 }
 
-func (f *Fs) Stat(name string) (fs.FileInfo, error) {
-	file, err := f.open(context.Background(), name)
+func (f *Fs) StatContext(ctx context.Context, name string) (fs.FileInfo, error) {
+	file, err := f.open(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -43,58 +92,104 @@ func (f *Fs) Stat(name string) (fs.FileInfo, error) {
 	return file, nil
 }
 
+// WithContext 返回使用 ctx 的文件系统视图，所有不带 ctx 参数的方法都使用该 ctx
+func (f *Fs) WithContext(ctx context.Context) fs.FS {
+	return f.withContext(ctx)
+}
+
+func (f *Fs) withContext(ctx context.Context) *Fs {
+	view := *f
+	view.ctx = ctx
+	return &view
+}
+
 func (f *Fs) Sub(dir string) (fs.FS, error) {
-	root := path.Join(f.root, dir)
-	return New(f.c, root), nil
+	sub := *f
+	sub.root = path.Join(f.root, dir)
+	return &sub, nil
+}
+
+// fullPath 返回 name 在网盘中的绝对路径，用作缓存的 key
+func (f *Fs) fullPath(name string) string {
+	return path.Join("/", f.root, name)
 }
 
 func (f *Fs) open(ctx context.Context, p string) (*File, error) {
-	root, err := f.c.DoGetRequest(ctx,
-		aliyundrive.GetRequest{FileId: aliyundrive.RootFileId})
-	if err != nil {
-		return nil, err
+	p = f.fullPath(p)
+	if item, ok := f.cache.get(p); ok {
+		return &File{fs: f, ctx: ctx, item: item, path: p}, nil
 	}
 
-	p = path.Join(f.root, p)
+	// 从最近的已缓存的上级目录开始逐级查找
+	var file *File
 	paths := splitPath(p)
-	file := &File{fs: f, item: &root.Item}
-
-	for _, name := range paths {
-		if name == "/" {
-			continue
+	start := 0
+	for i := len(paths) - 1; i > 0; i-- {
+		dir := path.Join(paths[:i]...)
+		if item, ok := f.cache.get(dir); ok {
+			file = &File{fs: f, ctx: ctx, item: item, path: dir}
+			start = i
+			break
 		}
-
-		next := ""
-		var targetItem *aliyundrive.Item
-		for {
-			var items []*aliyundrive.Item
-			items, next, err = file.list(ctx, aliyundrive.LimitMax, next)
-			if err != nil {
-				return nil, err
-			}
-			for _, item := range items {
-				if item.Name == name {
-					targetItem = item
-					break
-				}
-			}
-			if targetItem != nil || next == "" {
-				break
-			}
+	}
+	if file == nil {
+		root, err := f.src.root(ctx)
+		if err != nil {
+			return nil, err
 		}
+		f.cache.put("/", root)
+		file = &File{fs: f, ctx: ctx, item: root, path: "/"}
+		start = 1
+	}
 
-		if targetItem == nil {
-			return nil, fs.ErrNotExist
+	for _, name := range paths[start:] {
+		var err error
+		file, err = file.lookup(ctx, name)
+		if err != nil {
+			return nil, err
 		}
-		file = &File{fs: f, item: targetItem}
 	}
 
 	return file, nil
 }
 
+// openParent 打开 p 的父目录，并返回 p 的文件名
+func (f *Fs) openParent(ctx context.Context, p string) (*File, string, error) {
+	dir, name := path.Split(path.Clean("/" + p))
+	if name == "" {
+		return nil, "", fs.ErrInvalid
+	}
+	parent, err := f.open(ctx, dir)
+	if err != nil {
+		return nil, "", err
+	}
+	if !parent.IsDir() {
+		return nil, "", fs.ErrInvalid
+	}
+	return parent, name, nil
+}
+
+// IDer 返回文件在网盘中的 file_id
+type IDer interface {
+	ID() string
+}
+
+// ContentHasher 返回文件内容的 sha1，可用于去重
+type ContentHasher interface {
+	ContentHash() string
+}
+
+// MimeTyper 返回网盘识别的文件 MIME 类型，可用于选择处理方式
+type MimeTyper interface {
+	MimeType() string
+}
+
 type File struct {
 	fs   *Fs
+	ctx  context.Context
 	item *aliyundrive.Item
+	// path 为文件的绝对路径，为空时表示未知，不会写入缓存
+	path string
 
 	cancel context.CancelFunc
 	body   io.ReadCloser
@@ -130,17 +225,33 @@ func (f *File) Info() (fs.FileInfo, error) {
 	return f, nil
 }
 
+// Sys 返回文件的原始元数据 *aliyundrive.Item
 func (f *File) Sys() any {
-	return nil
+	return f.item
+}
+
+func (f *File) ID() string {
+	return f.item.FileId
+}
+
+func (f *File) ContentHash() string {
+	return f.item.ContentHash
+}
+
+func (f *File) MimeType() string {
+	return f.item.MimeType
 }
 
 func (f *File) Stat() (fs.FileInfo, error) {
 	return f, nil
 }
 func (f *File) Read(p []byte) (int, error) {
-	// 第一次读，初始化
+	if f.offset >= f.Size() {
+		return 0, io.EOF
+	}
+	// 第一次读或 Seek 之后，初始化
 	if f.body == nil {
-		err := f.prepareReader(context.Background(), 0)
+		err := f.prepareReader(f.ctx, f.offset)
 		if err != nil {
 			return 0, err
 		}
@@ -150,8 +261,18 @@ func (f *File) Read(p []byte) (int, error) {
 	return n, err
 }
 
+// Seek 只记录偏移量，下一次 Read 时才从新的位置开始下载
 func (f *File) Seek(offset int64, whence int) (int64, error) {
-	if whence != io.SeekStart {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.Size()
+	default:
+		return 0, fs.ErrInvalid
+	}
+	if offset < 0 {
 		return 0, fs.ErrInvalid
 	}
 
@@ -160,10 +281,7 @@ func (f *File) Seek(offset int64, whence int) (int64, error) {
 	}
 
 	f.close()
-	err := f.prepareReader(context.Background(), offset)
-	if err != nil {
-		return 0, err
-	}
+	f.offset = offset
 	return offset, nil
 }
 
@@ -171,33 +289,32 @@ func (f *File) ReadDir(n int) (entries []fs.DirEntry, err error) {
 	if n > 1 {
 		return nil, fs.ErrInvalid
 	}
-	ctx := context.Background()
-	next := ""
-	var items []*aliyundrive.Item
-	for {
-		items, next, err = f.list(ctx, aliyundrive.LimitMax, next)
-		if err != nil {
-			return
-		}
-		for _, item := range items {
-			entries = append(entries, &File{fs: f.fs, item: item})
-		}
-		if next == "" {
-			break
-		}
-	}
+	err = f.pager().ForEach(f.ctx, func(item *aliyundrive.Item) error {
+		entries = append(entries, f.child(item))
+		return nil
+	})
 	return
 }
 
+func (f *File) Readdir(count int) ([]fs.FileInfo, error) {
+	entries, err := f.ReadDir(count)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]fs.FileInfo, len(entries))
+	for i, entry := range entries {
+		infos[i] = entry.(*File)
+	}
+	return infos, nil
+}
+
 func (f *File) Close() error {
 	return f.close()
 }
 
 func (f *File) prepareReader(ctx context.Context, offset int64) error {
 	// 获取下载链接
-	getDownloadUrlResp, err := f.fs.c.DoGetDownloadUrlRequest(ctx, aliyundrive.GetDownloadUrlRequest{
-		FileId: f.item.FileId,
-	})
+	url, err := f.fs.src.downloadUrl(ctx, f.item.FileId)
 	if err != nil {
 		return err
 	}
@@ -207,15 +324,22 @@ func (f *File) prepareReader(ctx context.Context, offset int64) error {
 	if offset > 0 {
 		header.Set("Range", fmt.Sprintf("bytes=%v-", offset))
 	}
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(ctx)
 	downloadResp, err := f.fs.c.DoDownloadFileRequest(ctx, aliyundrive.DownloadFileRequest{
-		Url:    getDownloadUrlResp.Url,
+		Url:    url,
 		Header: header,
 	})
 	if err != nil {
 		cancel()
 		return err
 	}
+	// 下载链接过期时返回 403，不支持 Range 时返回 200 和完整的数据，都不能作为文件内容
+	status := downloadResp.StatusCode
+	if (status != http.StatusOK && status != http.StatusPartialContent) || (status == http.StatusOK && offset > 0) {
+		downloadResp.Reader.Close()
+		cancel()
+		return &aliyundrive.ErrorResponse{Code: strconv.Itoa(status), Message: http.StatusText(status)}
+	}
 	f.body = downloadResp.Reader
 	f.cancel = cancel
 	f.offset = offset
@@ -235,25 +359,44 @@ func (f *File) close() error {
 	return err
 }
 
+func (f *File) lookup(ctx context.Context, name string) (*File, error) {
+	pager := f.pager()
+	for pager.Next(ctx) {
+		if pager.Item().Name == name {
+			child := f.child(pager.Item())
+			child.ctx = ctx
+			return child, nil
+		}
+	}
+	if pager.Err() != nil {
+		return nil, pager.Err()
+	}
+	return nil, fs.ErrNotExist
+}
+
+// child 返回目录中的文件，目录路径已知时同时写入缓存
+func (f *File) child(item *aliyundrive.Item) *File {
+	child := &File{fs: f.fs, ctx: f.ctx, item: item}
+	if f.path != "" {
+		child.path = path.Join(f.path, item.Name)
+		f.fs.cache.put(child.path, item)
+	}
+	return child
+}
+
+func (f *File) pager() *aliyundrive.Pager[*aliyundrive.Item] {
+	return aliyundrive.NewPager("", func(ctx context.Context, marker string) ([]*aliyundrive.Item, string, error) {
+		return f.list(ctx, aliyundrive.LimitMax, marker)
+	})
+}
+
 func (f *File) list(ctx context.Context, limit int, next string) (items []*aliyundrive.Item, nextMarker string, err error) {
 	if !f.IsDir() {
 		err = fs.ErrInvalid
 		return
 	}
 
-	resp, err := f.fs.c.DoListRequest(ctx, aliyundrive.ListRequest{
-		ParentFileId:   f.item.FileId,
-		OrderBy:        aliyundrive.OrderByName,
-		OrderDirection: aliyundrive.OrderDirectionAsc,
-		Limit:          limit,
-		NextMarker:     next,
-	})
-	if err != nil {
-		return
-	}
-	nextMarker = resp.NextMarker
-	items = resp.Items
-	return
+	return f.fs.src.list(ctx, f.item.FileId, limit, next)
 }
 
 func splitPath(p string) []string {
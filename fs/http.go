@@ -0,0 +1,96 @@
+package fs
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"path"
+
+	"github.com/xbugio/aliyundrive-go-sdk"
+)
+
+// HTTPFileSystem 是代替 http.FileServer 使用的 http.Handler，文件返回 ContentHash 作为 ETag、
+// UpdatedAt 作为 Last-Modified、MimeType 作为 Content-Type，支持 Range 请求，
+// 并可以通过 WithRedirect 重定向到下载链接。目录的 index.html 和文件列表由 http.FileServer 处理。
+// http.FileServer 无法设置 ETag 和 Content-Type，因此它不实现 http.FileSystem。
+type HTTPFileSystem struct {
+	fs       *Fs
+	redirect bool
+}
+
+type httpOptionFunc func(h *HTTPFileSystem)
+
+// WithRedirect 对文件返回 302 重定向到下载链接，而不是代理数据
+func WithRedirect(redirect bool) httpOptionFunc {
+	return func(h *HTTPFileSystem) {
+		h.redirect = redirect
+	}
+}
+
+// HTTP 创建 HTTPFileSystem，用法与 http.FileServer 相同，如 http.Handle("/", fs.HTTP(c, "/"))
+func HTTP(c *aliyundrive.Drive, root string, options ...httpOptionFunc) *HTTPFileSystem {
+	h := &HTTPFileSystem{fs: New(c, root).(*Fs)}
+	for _, setOption := range options {
+		setOption(h)
+	}
+	return h
+}
+
+// httpDir 是 http.FileServer 处理目录时使用的 http.FileSystem
+type httpDir struct {
+	fs *Fs
+}
+
+func (d *httpDir) Open(name string) (http.File, error) {
+	file, err := d.fs.open(d.fs.ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (h *HTTPFileSystem) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := path.Clean("/" + r.URL.Path)
+	file, err := h.fs.open(r.Context(), name)
+	if err != nil {
+		code := toHTTPError(err)
+		http.Error(w, http.StatusText(code), code)
+		return
+	}
+	defer file.Close()
+
+	// 目录交给 http.FileServer 处理 index.html 和目录列表
+	if file.IsDir() {
+		http.FileServer(&httpDir{fs: h.fs.withContext(r.Context())}).ServeHTTP(w, r)
+		return
+	}
+
+	if h.redirect {
+		url, err := h.fs.src.downloadUrl(r.Context(), file.item.FileId)
+		if err != nil {
+			code := toHTTPError(err)
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+		http.Redirect(w, r, url, http.StatusFound)
+		return
+	}
+
+	if file.ContentHash() != "" {
+		w.Header().Set("ETag", `"`+file.ContentHash()+`"`)
+	}
+	if file.MimeType() != "" {
+		w.Header().Set("Content-Type", file.MimeType())
+	}
+	http.ServeContent(w, r, file.Name(), file.ModTime(), file)
+}
+
+func toHTTPError(err error) int {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return http.StatusNotFound
+	case errors.Is(err, fs.ErrPermission):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
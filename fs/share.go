@@ -0,0 +1,61 @@
+package fs
+
+import (
+	"context"
+	"io/fs"
+	"path"
+
+	"github.com/xbugio/aliyundrive-go-sdk"
+)
+
+// ShareFs 是他人分享的只读文件系统
+type ShareFs struct {
+	fs *Fs
+}
+
+func NewShare(c *aliyundrive.Drive, shareId string, sharePwd string, root string) fs.FS {
+	return &ShareFs{fs: &Fs{
+		c:    c,
+		ctx:  context.Background(),
+		root: root,
+		src: &shareSource{
+			c:            c,
+			shareId:      shareId,
+			tokenManager: aliyundrive.NewShareTokenManager(c, shareId, sharePwd),
+		},
+	}}
+}
+
+func (f *ShareFs) Open(name string) (fs.File, error) {
+	return f.fs.Open(name)
+}
+
+func (f *ShareFs) ReadDir(name string) ([]fs.DirEntry, error) {
+	return f.fs.ReadDir(name)
+}
+
+func (f *ShareFs) Stat(name string) (fs.FileInfo, error) {
+	return f.fs.Stat(name)
+}
+
+func (f *ShareFs) Sub(dir string) (fs.FS, error) {
+	sub := *f.fs
+	sub.root = path.Join(f.fs.root, dir)
+	return &ShareFs{fs: &sub}, nil
+}
+
+func (f *ShareFs) OpenContext(ctx context.Context, name string) (fs.File, error) {
+	return f.fs.OpenContext(ctx, name)
+}
+
+func (f *ShareFs) ReadDirContext(ctx context.Context, name string) ([]fs.DirEntry, error) {
+	return f.fs.ReadDirContext(ctx, name)
+}
+
+func (f *ShareFs) StatContext(ctx context.Context, name string) (fs.FileInfo, error) {
+	return f.fs.StatContext(ctx, name)
+}
+
+func (f *ShareFs) WithContext(ctx context.Context) fs.FS {
+	return &ShareFs{fs: f.fs.withContext(ctx)}
+}
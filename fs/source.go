@@ -0,0 +1,94 @@
+package fs
+
+import (
+	"context"
+
+	"github.com/xbugio/aliyundrive-go-sdk"
+)
+
+// source 是文件系统读取数据的来源，可以是自己的网盘或者他人的分享
+type source interface {
+	root(ctx context.Context) (*aliyundrive.Item, error)
+	list(ctx context.Context, parentFileId string, limit int, marker string) ([]*aliyundrive.Item, string, error)
+	downloadUrl(ctx context.Context, fileId string) (string, error)
+}
+
+type driveSource struct {
+	c *aliyundrive.Drive
+}
+
+func (s *driveSource) root(ctx context.Context) (*aliyundrive.Item, error) {
+	resp, err := s.c.DoGetRequest(ctx, aliyundrive.GetRequest{FileId: aliyundrive.RootFileId})
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Item, nil
+}
+
+func (s *driveSource) list(ctx context.Context, parentFileId string, limit int, marker string) ([]*aliyundrive.Item, string, error) {
+	resp, err := s.c.DoListRequest(ctx, aliyundrive.ListRequest{
+		ParentFileId:   parentFileId,
+		OrderBy:        aliyundrive.OrderByName,
+		OrderDirection: aliyundrive.OrderDirectionAsc,
+		Limit:          limit,
+		NextMarker:     marker,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Items, resp.NextMarker, nil
+}
+
+func (s *driveSource) downloadUrl(ctx context.Context, fileId string) (string, error) {
+	resp, err := s.c.DoGetDownloadUrlRequest(ctx, aliyundrive.GetDownloadUrlRequest{FileId: fileId})
+	if err != nil {
+		return "", err
+	}
+	return resp.Url, nil
+}
+
+type shareSource struct {
+	c            *aliyundrive.Drive
+	shareId      string
+	tokenManager aliyundrive.ShareTokenManager
+}
+
+func (s *shareSource) root(ctx context.Context) (*aliyundrive.Item, error) {
+	return &aliyundrive.Item{FileId: aliyundrive.RootFileId, Type: "folder"}, nil
+}
+
+func (s *shareSource) list(ctx context.Context, parentFileId string, limit int, marker string) ([]*aliyundrive.Item, string, error) {
+	shareToken, err := s.tokenManager.ShareToken(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := s.c.DoListByShareRequest(ctx, aliyundrive.ListByShareRequest{
+		ShareToken:     shareToken,
+		ShareId:        s.shareId,
+		ParentFileId:   parentFileId,
+		OrderBy:        aliyundrive.OrderByName,
+		OrderDirection: aliyundrive.OrderDirectionAsc,
+		Limit:          limit,
+		NextMarker:     marker,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Items, resp.NextMarker, nil
+}
+
+func (s *shareSource) downloadUrl(ctx context.Context, fileId string) (string, error) {
+	shareToken, err := s.tokenManager.ShareToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.c.DoGetShareDownloadUrlRequest(ctx, aliyundrive.GetShareDownloadUrlRequest{
+		ShareToken: shareToken,
+		ShareId:    s.shareId,
+		FileId:     fileId,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Url, nil
+}
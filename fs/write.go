@@ -0,0 +1,446 @@
+package fs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"time"
+
+	"github.com/xbugio/aliyundrive-go-sdk"
+)
+
+var ErrNotEmpty = errors.New("directory not empty")
+
+// WriterFile 是以写模式打开的文件，数据在 Close 时上传
+type WriterFile interface {
+	fs.File
+	Write(p []byte) (int, error)
+}
+
+type CreateFS interface {
+	fs.FS
+	Create(name string) (WriterFile, error)
+}
+
+type OpenFileFS interface {
+	fs.FS
+	OpenFile(name string, flag int, perm fs.FileMode) (fs.File, error)
+}
+
+type MkdirFS interface {
+	fs.FS
+	Mkdir(name string, perm fs.FileMode) error
+}
+
+type MkdirAllFS interface {
+	fs.FS
+	MkdirAll(name string, perm fs.FileMode) error
+}
+
+type RemoveFS interface {
+	fs.FS
+	Remove(name string) error
+}
+
+type RemoveAllFS interface {
+	fs.FS
+	RemoveAll(name string) error
+}
+
+type RenameFS interface {
+	fs.FS
+	Rename(oldname, newname string) error
+}
+
+type CopyFS interface {
+	fs.FS
+	Copy(oldname, newname string) error
+}
+
+func (f *Fs) Create(name string) (WriterFile, error) {
+	w, err := f.create(f.ctx, name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// OpenFile 只读模式等同于 Open；写模式总是替换文件的全部内容，不支持 O_APPEND
+func (f *Fs) OpenFile(name string, flag int, perm fs.FileMode) (fs.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		file, err := f.open(f.ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		return file, nil
+	}
+	w, err := f.create(f.ctx, name, flag)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (f *Fs) Mkdir(name string, perm fs.FileMode) error {
+	ctx := f.ctx
+	parent, base, err := f.openParent(ctx, name)
+	if err != nil {
+		return err
+	}
+	_, err = parent.lookup(ctx, base)
+	if err == nil {
+		return fs.ErrExist
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	_, err = f.c.DoCreateFolderRequest(ctx, aliyundrive.CreateFolderRequest{
+		Name:         base,
+		ParentFileId: parent.item.FileId,
+	})
+	return err
+}
+
+func (f *Fs) MkdirAll(name string, perm fs.FileMode) error {
+	ctx := f.ctx
+	dir, err := f.open(ctx, "/")
+	if err != nil {
+		return err
+	}
+
+	for _, base := range splitPath(path.Join("/", name)) {
+		if base == "/" {
+			continue
+		}
+
+		next, err := dir.lookup(ctx, base)
+		if err == nil {
+			if !next.IsDir() {
+				return fs.ErrExist
+			}
+			dir = next
+			continue
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		createFolderResp, err := f.c.DoCreateFolderRequest(ctx, aliyundrive.CreateFolderRequest{
+			Name:         base,
+			ParentFileId: dir.item.FileId,
+		})
+		if err != nil {
+			return err
+		}
+		dir = &File{fs: f, ctx: ctx, item: &aliyundrive.Item{
+			FileId:       createFolderResp.FileId,
+			Name:         base,
+			ParentFileId: dir.item.FileId,
+			Type:         "folder",
+		}}
+	}
+	return nil
+}
+
+func (f *Fs) Remove(name string) error {
+	ctx := f.ctx
+	file, err := f.open(ctx, name)
+	if err != nil {
+		return err
+	}
+	if file.item.FileId == aliyundrive.RootFileId {
+		return fs.ErrPermission
+	}
+	if file.IsDir() {
+		items, _, err := file.list(ctx, 1, "")
+		if err != nil {
+			return err
+		}
+		if len(items) > 0 {
+			return ErrNotEmpty
+		}
+	}
+	defer f.cache.invalidate(file.path)
+	return f.remove(ctx, file.item)
+}
+
+func (f *Fs) RemoveAll(name string) error {
+	ctx := f.ctx
+	file, err := f.open(ctx, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if file.item.FileId == aliyundrive.RootFileId {
+		return fs.ErrPermission
+	}
+	defer f.cache.invalidate(file.path)
+	return f.remove(ctx, file.item)
+}
+
+// Rename 目标为已存在的文件时会被替换，目标为已存在的目录时返回 fs.ErrExist；
+// 被替换的文件在新文件就位后才会删除
+func (f *Fs) Rename(oldname, newname string) error {
+	ctx := f.ctx
+	file, err := f.open(ctx, oldname)
+	if err != nil {
+		return err
+	}
+	if file.item.FileId == aliyundrive.RootFileId {
+		return fs.ErrPermission
+	}
+	parent, base, err := f.openParent(ctx, newname)
+	if err != nil {
+		return err
+	}
+	// 查找目标时会写入缓存，结束后再使两个路径失效
+	defer f.cache.invalidate(file.path)
+	defer f.cache.invalidate(f.fullPath(newname))
+
+	var replaced *aliyundrive.Item
+	target, err := parent.lookup(ctx, base)
+	if err == nil {
+		if target.item.FileId == file.item.FileId {
+			return nil
+		}
+		if target.IsDir() || file.IsDir() {
+			return fs.ErrExist
+		}
+		replaced = target.item
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	name := file.item.Name
+	if file.item.ParentFileId != parent.item.FileId {
+		// 先改为临时名称再移动，避免和目标目录中的同名文件冲突
+		if name != base || replaced != nil {
+			err = f.rename(ctx, file.item, tempName(file.item))
+			if err != nil {
+				return err
+			}
+			name = tempName(file.item)
+		}
+		_, err = f.c.DoMoveRequest(ctx, aliyundrive.MoveRequest{
+			FileId:         file.item.FileId,
+			ToParentFileId: parent.item.FileId,
+		})
+		if err != nil {
+			if name != file.item.Name {
+				f.rename(ctx, file.item, file.item.Name)
+			}
+			return err
+		}
+	}
+	return f.replace(ctx, file.item, name, base, replaced)
+}
+
+// Copy 在服务端复制文件或目录，目标已存在时返回 fs.ErrExist
+func (f *Fs) Copy(oldname, newname string) error {
+	ctx := f.ctx
+	file, err := f.open(ctx, oldname)
+	if err != nil {
+		return err
+	}
+	if file.item.FileId == aliyundrive.RootFileId {
+		return fs.ErrPermission
+	}
+	parent, base, err := f.openParent(ctx, newname)
+	if err != nil {
+		return err
+	}
+	_, err = parent.lookup(ctx, base)
+	if err == nil {
+		return fs.ErrExist
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	copyResp, err := f.c.DoCopyRequest(ctx, aliyundrive.CopyRequest{
+		FileId:         file.item.FileId,
+		ToParentFileId: parent.item.FileId,
+		NewName:        base,
+	})
+	if err != nil {
+		return err
+	}
+	// 复制文件夹是异步任务，等待完成后再返回
+	_, err = f.c.WaitTask(ctx, copyResp.AsyncTaskId)
+	return err
+}
+
+func (f *Fs) create(ctx context.Context, name string, flag int) (*writerFile, error) {
+	if flag&os.O_APPEND != 0 {
+		return nil, fs.ErrInvalid
+	}
+	parent, base, err := f.openParent(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := parent.lookup(ctx, base)
+	switch {
+	case err == nil:
+		if flag&os.O_EXCL != 0 && flag&os.O_CREATE != 0 {
+			return nil, fs.ErrExist
+		}
+		if existing.IsDir() {
+			return nil, fs.ErrInvalid
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		if flag&os.O_CREATE == 0 {
+			return nil, err
+		}
+		existing = nil
+	default:
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp("", "aliyundrive-*")
+	if err != nil {
+		return nil, err
+	}
+	w := &writerFile{
+		fs:   f,
+		ctx:  ctx,
+		tmp:  tmp,
+		path: f.fullPath(name),
+		item: &aliyundrive.Item{
+			Name:         base,
+			ParentFileId: parent.item.FileId,
+			Type:         "file",
+			UpdatedAt:    time.Now(),
+		},
+	}
+	if existing != nil {
+		w.existing = existing.item
+	}
+	return w, nil
+}
+
+func (f *Fs) remove(ctx context.Context, item *aliyundrive.Item) error {
+	if f.permanent {
+		deleteResp, err := f.c.DoDeleteRequest(ctx, aliyundrive.DeleteRequest{FileId: item.FileId})
+		if err != nil {
+			return err
+		}
+		_, err = f.c.WaitTask(ctx, deleteResp.AsyncTaskId)
+		return err
+	}
+	trashResp, err := f.c.DoTrashRequest(ctx, aliyundrive.TrashRequest{FileId: item.FileId})
+	if err != nil {
+		return err
+	}
+	_, err = f.c.WaitTask(ctx, trashResp.AsyncTaskId)
+	return err
+}
+
+// replace 把 item 从当前的名称 name 改为 base。replaced 不为空时先把它改为临时名称，
+// item 改名成功后再删除，改名失败时尽量恢复 replaced 原来的名称
+func (f *Fs) replace(ctx context.Context, item *aliyundrive.Item, name string, base string, replaced *aliyundrive.Item) error {
+	if replaced != nil {
+		err := f.rename(ctx, replaced, tempName(replaced))
+		if err != nil {
+			return err
+		}
+	}
+	if name != base {
+		err := f.rename(ctx, item, base)
+		if err != nil {
+			if replaced != nil {
+				f.rename(ctx, replaced, replaced.Name)
+			}
+			return err
+		}
+	}
+	if replaced == nil {
+		return nil
+	}
+	return f.remove(ctx, replaced)
+}
+
+func (f *Fs) rename(ctx context.Context, item *aliyundrive.Item, name string) error {
+	_, err := f.c.DoRenameRequest(ctx, aliyundrive.RenameRequest{
+		FileId: item.FileId,
+		Name:   name,
+	})
+	return err
+}
+
+// tempName 是替换文件时使用的临时名称，包含文件 id，不会和其他文件重名
+func tempName(item *aliyundrive.Item) string {
+	return ".~" + item.FileId
+}
+
+type writerFile struct {
+	fs       *Fs
+	ctx      context.Context
+	existing *aliyundrive.Item
+	item     *aliyundrive.Item
+	path     string
+	tmp      *os.File
+	closed   bool
+}
+
+func (w *writerFile) Stat() (fs.FileInfo, error) {
+	return &File{fs: w.fs, ctx: w.ctx, item: w.item}, nil
+}
+
+func (w *writerFile) Read(p []byte) (int, error) {
+	return 0, fs.ErrPermission
+}
+
+func (w *writerFile) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, fs.ErrClosed
+	}
+	n, err := w.tmp.Write(p)
+	w.item.Size += uint64(n)
+	return n, err
+}
+
+// Close 上传缓存的数据，成功后才会替换同名的旧文件
+func (w *writerFile) Close() error {
+	if w.closed {
+		return fs.ErrClosed
+	}
+	w.closed = true
+	defer os.Remove(w.tmp.Name())
+	defer w.tmp.Close()
+
+	ctx := w.ctx
+	_, err := w.tmp.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	uploadResp, err := w.fs.c.Upload(ctx, aliyundrive.UploadRequest{
+		Name:         w.item.Name,
+		ParentFileId: w.item.ParentFileId,
+		Size:         w.item.Size,
+		Reader:       w.tmp,
+	})
+	if err != nil {
+		return err
+	}
+
+	name := w.item.Name
+	*w.item = uploadResp.Item
+	defer w.fs.cache.invalidate(w.path)
+	if w.existing == nil {
+		return nil
+	}
+
+	// 旧文件存在时上传的文件会被自动重命名
+	err = w.fs.replace(ctx, w.item, w.item.Name, name, w.existing)
+	if err != nil {
+		return err
+	}
+	w.item.Name = name
+	return nil
+}
@@ -0,0 +1,290 @@
+package hls
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xbugio/aliyundrive-go-sdk"
+)
+
+const playlistContentType = "application/vnd.apple.mpegurl"
+
+// DefaultSegmentTTL 是签名地址的默认有效期，需要长于视频的播放时长
+const DefaultSegmentTTL = 6 * time.Hour
+
+// Proxy 把网盘转码后的 HLS 播放列表代理到本地，播放列表中的分片地址
+// 会被改写为经过签名的本地地址，由 Proxy 带上 Referer 后转发给网盘。
+//
+//	GET {prefix}/{fileId}.m3u8[?template=HD]  播放列表
+//	GET {prefix}/segment?u=...&e=...&s=...    分片或子播放列表，e 为过期时间
+type Proxy struct {
+	drive    *aliyundrive.Drive
+	prefix   string
+	key      []byte
+	ttl      time.Duration
+	template string
+	errorLog *log.Logger
+}
+
+type optionFunc func(p *Proxy)
+
+// WithKey 指定签名密钥，多个实例共享同一密钥时签名的地址可以互相使用
+func WithKey(key []byte) optionFunc {
+	return func(p *Proxy) {
+		p.key = key
+	}
+}
+
+// WithSegmentTTL 指定签名地址的有效期，默认为 DefaultSegmentTTL
+func WithSegmentTTL(ttl time.Duration) optionFunc {
+	return func(p *Proxy) {
+		if ttl > 0 {
+			p.ttl = ttl
+		}
+	}
+}
+
+// WithTemplate 指定默认的转码模板，如 aliyundrive.VideoTemplateHD，为空时使用最高分辨率
+func WithTemplate(templateId string) optionFunc {
+	return func(p *Proxy) {
+		p.template = templateId
+	}
+}
+
+// WithErrorLog 指定记录上游错误的 logger，默认使用 log 包的标准 logger。
+// 上游错误只记录在日志中，返回给客户端的只有状态码。
+func WithErrorLog(logger *log.Logger) optionFunc {
+	return func(p *Proxy) {
+		if logger != nil {
+			p.errorLog = logger
+		}
+	}
+}
+
+// NewProxy 创建 HLS 代理，prefix 为挂载的 URL 路径前缀。
+// 没有指定密钥时随机生成，无法读取随机数时 panic，避免使用可以预测的密钥。
+func NewProxy(c *aliyundrive.Drive, prefix string, options ...optionFunc) *Proxy {
+	p := &Proxy{
+		drive:    c,
+		prefix:   strings.TrimSuffix(prefix, "/"),
+		ttl:      DefaultSegmentTTL,
+		errorLog: log.Default(),
+	}
+	for _, setOption := range options {
+		setOption(p)
+	}
+	if p.key == nil {
+		p.key = make([]byte, 32)
+		_, err := rand.Read(p.key)
+		if err != nil {
+			panic("hls: generate key: " + err.Error())
+		}
+	}
+	return p
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, p.prefix+"/") {
+		http.NotFound(w, r)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, p.prefix+"/")
+
+	switch {
+	case name == "segment":
+		p.serveSegment(w, r)
+	case strings.HasSuffix(name, ".m3u8") && !strings.Contains(name, "/"):
+		p.servePlaylist(w, r, strings.TrimSuffix(name, ".m3u8"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (p *Proxy) servePlaylist(w http.ResponseWriter, r *http.Request, fileId string) {
+	resp, err := p.drive.DoGetVideoPreviewPlayInfoRequest(r.Context(), aliyundrive.GetVideoPreviewPlayInfoRequest{
+		FileId: fileId,
+	})
+	if err != nil {
+		p.badGateway(w, r, err)
+		return
+	}
+
+	info := &resp.VideoPreviewPlayInfo
+	template := r.URL.Query().Get("template")
+	if template == "" {
+		template = p.template
+	}
+	task, ok := info.Task(template)
+	if !ok {
+		task, ok = info.BestTask()
+	}
+	if !ok {
+		http.Error(w, "no finished transcoding task", http.StatusNotFound)
+		return
+	}
+	p.proxyPlaylist(w, r, task.Url)
+}
+
+func (p *Proxy) serveSegment(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	raw, err := base64.RawURLEncoding.DecodeString(query.Get("u"))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	upstream := string(raw)
+	expires, err := strconv.ParseInt(query.Get("e"), 10, 64)
+	if err != nil || time.Now().Unix() > expires || !hmac.Equal([]byte(p.sign(upstream, expires)), []byte(query.Get("s"))) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	u, err := url.Parse(upstream)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if strings.HasSuffix(u.Path, ".m3u8") {
+		p.proxyPlaylist(w, r, upstream)
+		return
+	}
+
+	header := make(http.Header)
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+		header.Set("Range", rangeHeader)
+	}
+	resp, err := p.drive.DoDownloadFileRequest(r.Context(), aliyundrive.DownloadFileRequest{
+		Url:    upstream,
+		Header: header,
+	})
+	if err != nil {
+		p.badGateway(w, r, err)
+		return
+	}
+	defer resp.Reader.Close()
+
+	for _, k := range []string{"Content-Type", "Content-Length", "Content-Range", "Accept-Ranges", "Last-Modified", "ETag"} {
+		if v := resp.Header.Get(k); v != "" {
+			w.Header().Set(k, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	if r.Method != "HEAD" {
+		io.Copy(w, resp.Reader)
+	}
+}
+
+// proxyPlaylist 获取 upstream 的播放列表并改写其中的地址
+func (p *Proxy) proxyPlaylist(w http.ResponseWriter, r *http.Request, upstream string) {
+	base, err := url.Parse(upstream)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := p.drive.DoDownloadFileRequest(r.Context(), aliyundrive.DownloadFileRequest{Url: upstream})
+	if err != nil {
+		p.badGateway(w, r, err)
+		return
+	}
+	defer resp.Reader.Close()
+	if resp.StatusCode != http.StatusOK {
+		http.Error(w, http.StatusText(resp.StatusCode), resp.StatusCode)
+		return
+	}
+
+	playlist, err := rewritePlaylist(resp.Reader, base, p.segmentUrl)
+	if err != nil {
+		p.badGateway(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", playlistContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	if r.Method != "HEAD" {
+		w.Write(playlist)
+	}
+}
+
+// badGateway 记录上游错误，只向客户端返回 502，避免泄露接口错误信息和签名链接
+func (p *Proxy) badGateway(w http.ResponseWriter, r *http.Request, err error) {
+	p.errorLog.Printf("hls: %v %v: %v", r.Method, r.URL.Path, err)
+	http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+}
+
+func (p *Proxy) segmentUrl(upstream string) string {
+	expires := time.Now().Add(p.ttl).Unix()
+	query := make(url.Values)
+	query.Set("u", base64.RawURLEncoding.EncodeToString([]byte(upstream)))
+	query.Set("e", strconv.FormatInt(expires, 10))
+	query.Set("s", p.sign(upstream, expires))
+	return p.prefix + "/segment?" + query.Encode()
+}
+
+// sign 对地址和过期时间签名
+func (p *Proxy) sign(upstream string, expires int64) string {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte(strconv.FormatInt(expires, 10) + "\n" + upstream))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// rewritePlaylist 把播放列表中的分片地址和标签中的 URI 属性解析为绝对地址后交给 rewrite 改写
+func rewritePlaylist(r io.Reader, base *url.URL, rewrite func(string) string) ([]byte, error) {
+	resolve := func(ref string) string {
+		u, err := base.Parse(ref)
+		if err != nil {
+			return ref
+		}
+		return rewrite(u.String())
+	}
+
+	out := new(bytes.Buffer)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#"):
+			line = rewriteURIAttr(line, resolve)
+		default:
+			line = resolve(line)
+		}
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// rewriteURIAttr 改写 #EXT-X-KEY、#EXT-X-MAP 等标签中的 URI="..." 属性
+func rewriteURIAttr(line string, resolve func(string) string) string {
+	const attr = `URI="`
+	start := strings.Index(line, attr)
+	if start < 0 {
+		return line
+	}
+	start += len(attr)
+	end := strings.IndexByte(line[start:], '"')
+	if end < 0 {
+		return line
+	}
+	end += start
+	return line[:start] + resolve(line[start:end]) + line[end:]
+}
@@ -0,0 +1,223 @@
+package aliyundrive
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const exifTimeLayout = "2006:01:02 15:04:05"
+
+type ImageMediaMetadata struct {
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	Time         string `json:"time"`
+	Location     string `json:"location"`
+	Country      string `json:"country"`
+	Province     string `json:"province"`
+	City         string `json:"city"`
+	District     string `json:"district"`
+	Township     string `json:"township"`
+	Exif         string `json:"exif"`
+	ImageQuality *struct {
+		OverallScore float64 `json:"overall_score"`
+	} `json:"image_quality,omitempty"`
+	ImageTags []*struct {
+		Name       string  `json:"name"`
+		Confidence float64 `json:"confidence"`
+		TagLevel   int     `json:"tag_level"`
+		ParentName string  `json:"parent_name"`
+	} `json:"image_tags,omitempty"`
+}
+
+type VideoMediaVideoStream struct {
+	Duration string `json:"duration"`
+	Clarity  string `json:"clarity"`
+	Fps      string `json:"fps"`
+	Bitrate  string `json:"bitrate"`
+	CodeName string `json:"code_name"`
+}
+
+type VideoMediaAudioStream struct {
+	Duration      string `json:"duration"`
+	Channels      int    `json:"channels"`
+	ChannelLayout string `json:"channel_layout"`
+	BitRate       string `json:"bit_rate"`
+	CodeName      string `json:"code_name"`
+	SampleRate    string `json:"sample_rate"`
+}
+
+type VideoMediaMetadata struct {
+	Width                 int                      `json:"width"`
+	Height                int                      `json:"height"`
+	Duration              string                   `json:"duration"`
+	Time                  string                   `json:"time"`
+	Location              string                   `json:"location"`
+	Country               string                   `json:"country"`
+	Province              string                   `json:"province"`
+	City                  string                   `json:"city"`
+	District              string                   `json:"district"`
+	Township              string                   `json:"township"`
+	VideoMediaVideoStream []*VideoMediaVideoStream `json:"video_media_video_stream,omitempty"`
+	VideoMediaAudioStream []*VideoMediaAudioStream `json:"video_media_audio_stream,omitempty"`
+}
+
+type VideoPreviewMetadata struct {
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	Duration     string `json:"duration"`
+	Bitrate      string `json:"bitrate"`
+	FrameRate    string `json:"frame_rate"`
+	AudioFormat  string `json:"audio_format"`
+	VideoFormat  string `json:"video_format"`
+	TemplateList []*struct {
+		TemplateId string `json:"template_id"`
+		Status     string `json:"status"`
+	} `json:"template_list,omitempty"`
+}
+
+// Resolution 返回图片或视频的宽高
+func (i *Item) Resolution() (width int, height int, ok bool) {
+	switch {
+	case i.ImageMediaMetadata != nil && i.ImageMediaMetadata.Width > 0:
+		return i.ImageMediaMetadata.Width, i.ImageMediaMetadata.Height, true
+	case i.VideoMediaMetadata != nil && i.VideoMediaMetadata.Width > 0:
+		return i.VideoMediaMetadata.Width, i.VideoMediaMetadata.Height, true
+	case i.VideoPreviewMetadata != nil && i.VideoPreviewMetadata.Width > 0:
+		return i.VideoPreviewMetadata.Width, i.VideoPreviewMetadata.Height, true
+	}
+	return 0, 0, false
+}
+
+// TakenAt 返回拍摄时间，EXIF 时间没有时区，按本地时区解析
+func (i *Item) TakenAt() (time.Time, bool) {
+	var s string
+	switch {
+	case i.ImageMediaMetadata != nil:
+		s = i.ImageMediaMetadata.Time
+	case i.VideoMediaMetadata != nil:
+		s = i.VideoMediaMetadata.Time
+	}
+	if s == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.ParseInLocation(exifTimeLayout, s, time.Local); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// Location 返回拍摄地点的纬度和经度
+func (i *Item) Location() (lat float64, lng float64, ok bool) {
+	var s string
+	switch {
+	case i.ImageMediaMetadata != nil:
+		s = i.ImageMediaMetadata.Location
+	case i.VideoMediaMetadata != nil:
+		s = i.VideoMediaMetadata.Location
+	}
+	latStr, lngStr, found := strings.Cut(s, ",")
+	if !found {
+		return 0, 0, false
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	lng, err = strconv.ParseFloat(strings.TrimSpace(lngStr), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return lat, lng, true
+}
+
+// Duration 返回视频或音频的时长
+func (i *Item) Duration() (time.Duration, bool) {
+	var s string
+	switch {
+	case i.VideoMediaMetadata != nil && i.VideoMediaMetadata.Duration != "":
+		s = i.VideoMediaMetadata.Duration
+	case i.VideoPreviewMetadata != nil:
+		s = i.VideoPreviewMetadata.Duration
+	}
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(seconds * float64(time.Second)), true
+}
+
+type ItemPredicate func(item *Item) bool
+
+// Filter 返回满足全部 predicates 的文件
+func (q ItemQuery) Filter(predicates ...ItemPredicate) ItemQuery {
+	result := make(ItemQuery, 0, len(q))
+	for _, i := range q {
+		matched := true
+		for _, predicate := range predicates {
+			if !predicate(i) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// MinResolution 匹配宽和高都不小于给定值的图片或视频，不区分横竖
+func MinResolution(width int, height int) ItemPredicate {
+	long, short := width, height
+	if long < short {
+		long, short = short, long
+	}
+	return func(item *Item) bool {
+		w, h, ok := item.Resolution()
+		if !ok {
+			return false
+		}
+		if w < h {
+			w, h = h, w
+		}
+		return w >= long && h >= short
+	}
+}
+
+// TakenBetween 匹配拍摄时间在 [from, to) 之间的文件，零值表示不限制
+func TakenBetween(from time.Time, to time.Time) ItemPredicate {
+	return func(item *Item) bool {
+		t, ok := item.TakenAt()
+		if !ok {
+			return false
+		}
+		return (from.IsZero() || !t.Before(from)) && (to.IsZero() || t.Before(to))
+	}
+}
+
+func HasLocation() ItemPredicate {
+	return func(item *Item) bool {
+		_, _, ok := item.Location()
+		return ok
+	}
+}
+
+// LocationWithin 匹配拍摄地点在给定经纬度范围内的文件
+func LocationWithin(minLat float64, minLng float64, maxLat float64, maxLng float64) ItemPredicate {
+	return func(item *Item) bool {
+		lat, lng, ok := item.Location()
+		return ok && lat >= minLat && lat <= maxLat && lng >= minLng && lng <= maxLng
+	}
+}
+
+// DurationBetween 匹配时长在 [min, max] 之间的文件，max 为 0 表示不限制
+func DurationBetween(min time.Duration, max time.Duration) ItemPredicate {
+	return func(item *Item) bool {
+		d, ok := item.Duration()
+		return ok && d >= min && (max == 0 || d <= max)
+	}
+}
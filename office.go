@@ -0,0 +1,116 @@
+package aliyundrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// OfficePreviewExtensions 是支持在线预览的文档扩展名
+var OfficePreviewExtensions = []string{
+	"doc", "docx", "dot", "dotx", "wps", "rtf",
+	"xls", "xlsx", "xlsm", "xlt", "xltx", "et", "csv",
+	"ppt", "pptx", "pps", "ppsx", "pot", "potx", "dps",
+	"pdf",
+}
+
+// IsOfficePreviewSupported 判断扩展名是否支持在线预览，可以在请求前用 Item.FileExtension 过滤
+func IsOfficePreviewSupported(fileExtension string) bool {
+	fileExtension = strings.ToLower(strings.TrimPrefix(fileExtension, "."))
+	for _, ext := range OfficePreviewExtensions {
+		if ext == fileExtension {
+			return true
+		}
+	}
+	return false
+}
+
+// OfficePreviewUnsupportedError 表示文件格式不支持在线预览，
+// Code 为空时表示请求前就根据扩展名拒绝，否则为服务端返回的错误码
+type OfficePreviewUnsupportedError struct {
+	FileId        string
+	FileExtension string
+	Code          string
+}
+
+func (e *OfficePreviewUnsupportedError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("aliyundrive: office preview not supported for file %v: %v", e.FileId, e.Code)
+	}
+	return fmt.Sprintf("aliyundrive: office preview not supported for file %v with extension %q", e.FileId, e.FileExtension)
+}
+
+func IsOfficePreviewUnsupportedError(err error) bool {
+	_, ok := err.(*OfficePreviewUnsupportedError)
+	return ok
+}
+
+// isOfficePreviewUnsupportedCode 判断服务端的错误码是否表示格式或大小不支持预览
+func isOfficePreviewUnsupportedCode(code string) bool {
+	switch code {
+	case "NotSupported.FileType", "NotSupported.FileSize", "InvalidParameter.FileType":
+		return true
+	}
+	return false
+}
+
+type GetOfficePreviewUrlRequest struct {
+	DriveId string `json:"-"`
+	FileId  string `json:"file_id"`
+	// FileExtension 为文件的扩展名，为空时先查询文件信息
+	FileExtension string `json:"-"`
+}
+
+type GetOfficePreviewUrlResponse struct {
+	PreviewUrl  string `json:"preview_url"`
+	AccessToken string `json:"access_token"`
+}
+
+// DoGetOfficePreviewUrlRequest 获取文档的在线预览链接，返回的 AccessToken 需要随预览链接一起交给前端。
+// 扩展名不在 OfficePreviewExtensions 中时不发送预览请求，直接返回 *OfficePreviewUnsupportedError。
+func (c *Drive) DoGetOfficePreviewUrlRequest(ctx context.Context, request GetOfficePreviewUrlRequest) (*GetOfficePreviewUrlResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+	if request.FileExtension == "" {
+		getResp, err := c.DoGetRequest(ctx, GetRequest{DriveId: driveId, FileId: request.FileId})
+		if err != nil {
+			return nil, err
+		}
+		request.FileExtension = getResp.FileExtension
+	}
+	if !IsOfficePreviewSupported(request.FileExtension) {
+		return nil, &OfficePreviewUnsupportedError{FileId: request.FileId, FileExtension: request.FileExtension}
+	}
+	accessToken, err := c.tokenManager.AccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &struct {
+		DriveId     string `json:"drive_id"`
+		AccessToken string `json:"access_token"`
+		GetOfficePreviewUrlRequest
+	}{
+		DriveId:                    driveId,
+		AccessToken:                accessToken,
+		GetOfficePreviewUrlRequest: request,
+	}
+
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/file/get_office_preview_url", params)
+	if errResponse, ok := err.(*ErrorResponse); ok && isOfficePreviewUnsupportedCode(errResponse.Code) {
+		return nil, &OfficePreviewUnsupportedError{FileId: request.FileId, FileExtension: request.FileExtension, Code: errResponse.Code}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(GetOfficePreviewUrlResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
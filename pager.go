@@ -0,0 +1,212 @@
+package aliyundrive
+
+import (
+	"context"
+)
+
+type page[T any] struct {
+	items  []T
+	marker string
+	err    error
+}
+
+// Pager 自动跟随 next_marker 逐条遍历分页接口的结果
+//
+//	pager := c.NewListPager(aliyundrive.ListRequest{ParentFileId: aliyundrive.RootFileId})
+//	for pager.Next(ctx) {
+//		item := pager.Item()
+//	}
+//	err := pager.Err()
+type Pager[T any] struct {
+	fetch    func(ctx context.Context, marker string) ([]T, string, error)
+	prefetch bool
+
+	items   []T
+	index   int
+	marker  string
+	started bool
+	pending chan page[T]
+	item    T
+	err     error
+}
+
+// NewPager 使用 fetch 创建 Pager，fetch 返回一页数据和下一页的 marker，marker 为空表示没有更多数据
+func NewPager[T any](marker string, fetch func(ctx context.Context, marker string) ([]T, string, error)) *Pager[T] {
+	return &Pager[T]{fetch: fetch, marker: marker}
+}
+
+// Prefetch 开启后，处理当前页时会并发请求下一页
+func (p *Pager[T]) Prefetch(prefetch bool) *Pager[T] {
+	p.prefetch = prefetch
+	return p
+}
+
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	for p.index >= len(p.items) {
+		if p.err != nil || (p.started && p.marker == "") {
+			return false
+		}
+		p.load(ctx)
+	}
+	p.item = p.items[p.index]
+	p.index++
+	return true
+}
+
+func (p *Pager[T]) Item() T {
+	return p.item
+}
+
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// ForEach 对每一条数据调用 fn，fn 返回错误时停止遍历并返回该错误
+func (p *Pager[T]) ForEach(ctx context.Context, fn func(item T) error) error {
+	for p.Next(ctx) {
+		err := fn(p.Item())
+		if err != nil {
+			return err
+		}
+	}
+	return p.Err()
+}
+
+// Chan 在后台遍历所有数据，遍历结束后关闭两个 channel，出错时错误会先写入 error channel。
+// 不再读取数据时必须取消 ctx，否则后台的 goroutine 会一直阻塞在发送上。
+func (p *Pager[T]) Chan(ctx context.Context) (<-chan T, <-chan error) {
+	items := make(chan T)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(items)
+		defer close(errs)
+		for p.Next(ctx) {
+			select {
+			case items <- p.Item():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+		if p.Err() != nil {
+			errs <- p.Err()
+		}
+	}()
+	return items, errs
+}
+
+func (p *Pager[T]) load(ctx context.Context) {
+	var pg page[T]
+	if p.pending != nil {
+		select {
+		case pg = <-p.pending:
+		case <-ctx.Done():
+			p.err = ctx.Err()
+			return
+		}
+		p.pending = nil
+	} else {
+		if err := ctx.Err(); err != nil {
+			p.err = err
+			return
+		}
+		pg.items, pg.marker, pg.err = p.fetch(ctx, p.marker)
+	}
+
+	p.started = true
+	if pg.err != nil {
+		p.err = pg.err
+		return
+	}
+	p.items = pg.items
+	p.index = 0
+	p.marker = pg.marker
+
+	if p.prefetch && p.marker != "" {
+		pending := make(chan page[T], 1)
+		marker := p.marker
+		go func() {
+			var pg page[T]
+			pg.items, pg.marker, pg.err = p.fetch(ctx, marker)
+			pending <- pg
+		}()
+		p.pending = pending
+	}
+}
+
+func (c *Drive) NewListPager(request ListRequest) *Pager[*Item] {
+	return NewPager(request.NextMarker, func(ctx context.Context, marker string) ([]*Item, string, error) {
+		request.NextMarker = marker
+		resp, err := c.DoListRequest(ctx, request)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Items, resp.NextMarker, nil
+	})
+}
+
+func (c *Drive) NewSearchPager(request SearchRequest) *Pager[*Item] {
+	return NewPager(request.NextMarker, func(ctx context.Context, marker string) ([]*Item, string, error) {
+		request.NextMarker = marker
+		resp, err := c.DoSearchRequest(ctx, request)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Items, resp.NextMarker, nil
+	})
+}
+
+func (c *Drive) NewTrashPager(request ListTrashRequest) *Pager[*Item] {
+	return NewPager(request.NextMarker, func(ctx context.Context, marker string) ([]*Item, string, error) {
+		request.NextMarker = marker
+		resp, err := c.DoListTrashRequest(ctx, request)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Items, resp.NextMarker, nil
+	})
+}
+
+func (c *Drive) NewStarredPager(request ListStarredRequest) *Pager[*Item] {
+	return NewPager(request.NextMarker, func(ctx context.Context, marker string) ([]*Item, string, error) {
+		request.NextMarker = marker
+		resp, err := c.DoListStarredRequest(ctx, request)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Items, resp.NextMarker, nil
+	})
+}
+
+func (c *Drive) NewRevisionPager(request ListRevisionsRequest) *Pager[*Revision] {
+	return NewPager(request.NextMarker, func(ctx context.Context, marker string) ([]*Revision, string, error) {
+		request.NextMarker = marker
+		resp, err := c.DoListRevisionsRequest(ctx, request)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Items, resp.NextMarker, nil
+	})
+}
+
+func (c *Drive) NewAlbumPager(request ListAlbumsRequest) *Pager[*Album] {
+	return NewPager(request.NextMarker, func(ctx context.Context, marker string) ([]*Album, string, error) {
+		request.NextMarker = marker
+		resp, err := c.DoListAlbumsRequest(ctx, request)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Items, resp.NextMarker, nil
+	})
+}
+
+func (c *Drive) NewAlbumFilesPager(request ListAlbumFilesRequest) *Pager[*Item] {
+	return NewPager(request.NextMarker, func(ctx context.Context, marker string) ([]*Item, string, error) {
+		request.NextMarker = marker
+		resp, err := c.DoListAlbumFilesRequest(ctx, request)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Items, resp.NextMarker, nil
+	})
+}
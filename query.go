@@ -0,0 +1,150 @@
+package aliyundrive
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryOp 是比较运算符，只能使用下面的常量，其他值会 panic，避免把任意文本拼接到查询中
+type QueryOp string
+
+const QueryOpEq QueryOp = "="
+const QueryOpGt QueryOp = ">"
+const QueryOpGte QueryOp = ">="
+const QueryOpLt QueryOp = "<"
+const QueryOpLte QueryOp = "<="
+
+func (op QueryOp) String() string {
+	switch op {
+	case QueryOpEq, QueryOpGt, QueryOpGte, QueryOpLt, QueryOpLte:
+		return string(op)
+	}
+	panic(fmt.Sprintf("aliyundrive: invalid query operator %q", string(op)))
+}
+
+// queryNone 不匹配任何文件，用于空的 in 列表
+var queryNone = Query{expr: "size < 0"}
+
+// Query 是 file/search 接口的查询条件，使用 String 得到 SearchRequest.Query
+type Query struct {
+	expr string
+	// group 表示 expr 由多个条件组合而成，嵌套时需要加括号
+	group bool
+}
+
+func (q Query) String() string {
+	return q.expr
+}
+
+func QueryNameMatch(name string) Query {
+	return Query{expr: "name match " + quote(name)}
+}
+
+func QueryNameEq(name string) Query {
+	return Query{expr: "name = " + quote(name)}
+}
+
+// QueryFileExtensionIn 没有参数时不匹配任何文件
+func QueryFileExtensionIn(extensions ...string) Query {
+	if len(extensions) == 0 {
+		return queryNone
+	}
+	return Query{expr: "file_extension in " + quoteList(extensions)}
+}
+
+// QueryCategoryIn 没有参数时不匹配任何文件
+func QueryCategoryIn(categories ...string) Query {
+	switch len(categories) {
+	case 0:
+		return queryNone
+	case 1:
+		return Query{expr: "category = " + quote(categories[0])}
+	}
+	return Query{expr: "category in " + quoteList(categories)}
+}
+
+func QueryTypeEq(t string) Query {
+	return Query{expr: "type = " + quote(t)}
+}
+
+func QuerySize(op QueryOp, size uint64) Query {
+	return Query{expr: "size " + op.String() + " " + strconv.FormatUint(size, 10)}
+}
+
+// QuerySizeBetween 包含 min 和 max
+func QuerySizeBetween(min uint64, max uint64) Query {
+	return QueryAnd(QuerySize(QueryOpGte, min), QuerySize(QueryOpLte, max))
+}
+
+func QueryCreatedAt(op QueryOp, t time.Time) Query {
+	return Query{expr: "created_at " + op.String() + " " + quote(formatQueryTime(t))}
+}
+
+func QueryUpdatedAt(op QueryOp, t time.Time) Query {
+	return Query{expr: "updated_at " + op.String() + " " + quote(formatQueryTime(t))}
+}
+
+func QueryParentFileIdEq(parentFileId string) Query {
+	return Query{expr: "parent_file_id = " + quote(parentFileId)}
+}
+
+func QueryStarred(starred bool) Query {
+	return Query{expr: "starred = " + strconv.FormatBool(starred)}
+}
+
+func QueryLabel(label string) Query {
+	return Query{expr: "label = " + quote(label)}
+}
+
+func QueryAnd(queries ...Query) Query {
+	return join("and", queries)
+}
+
+func QueryOr(queries ...Query) Query {
+	return join("or", queries)
+}
+
+func join(op string, queries []Query) Query {
+	var nonEmpty []Query
+	for _, q := range queries {
+		if q.expr != "" {
+			nonEmpty = append(nonEmpty, q)
+		}
+	}
+	switch len(nonEmpty) {
+	case 0:
+		return Query{}
+	case 1:
+		return nonEmpty[0]
+	}
+
+	exprs := make([]string, len(nonEmpty))
+	for i, q := range nonEmpty {
+		if q.group {
+			exprs[i] = "(" + q.expr + ")"
+		} else {
+			exprs[i] = q.expr
+		}
+	}
+	return Query{expr: strings.Join(exprs, " "+op+" "), group: true}
+}
+
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+func quoteList(list []string) string {
+	quoted := make([]string, len(list))
+	for i, s := range list {
+		quoted[i] = quote(s)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func formatQueryTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05")
+}
@@ -0,0 +1,156 @@
+package aliyundrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type Privilege struct {
+	FeatureId     string `json:"feature_id"`
+	FeatureAttrId string `json:"feature_attr_id"`
+	Quota         int    `json:"quota"`
+}
+
+type PersonalRightsInfo struct {
+	Name       string       `json:"name"`
+	SpuId      string       `json:"spu_id"`
+	IsExpires  bool         `json:"is_expires"`
+	Privileges []*Privilege `json:"privileges"`
+}
+
+// Privilege 按 featureId 查找权益，featureAttrId 为空时返回第一个匹配 featureId 的权益
+func (i *PersonalRightsInfo) Privilege(featureId string, featureAttrId string) (*Privilege, bool) {
+	for _, p := range i.Privileges {
+		if p.FeatureId == featureId && (featureAttrId == "" || p.FeatureAttrId == featureAttrId) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// HasPrivilege 判断是否拥有 featureId 对应的权益
+func (i *PersonalRightsInfo) HasPrivilege(featureId string) bool {
+	_, ok := i.Privilege(featureId, "")
+	return ok
+}
+
+// PrivilegeQuota 返回 featureId 对应权益的额度
+func (i *PersonalRightsInfo) PrivilegeQuota(featureId string) (int, bool) {
+	p, ok := i.Privilege(featureId, "")
+	if !ok {
+		return 0, false
+	}
+	return p.Quota, true
+}
+
+type PersonalSpaceInfo struct {
+	TotalSize uint64 `json:"total_size"`
+	UsedSize  uint64 `json:"used_size"`
+}
+
+// RemainingSize 返回剩余空间，已用空间超过总空间时返回 0
+func (i *PersonalSpaceInfo) RemainingSize() uint64 {
+	if i.UsedSize >= i.TotalSize {
+		return 0
+	}
+	return i.TotalSize - i.UsedSize
+}
+
+type GetSpaceInfoRequest struct {
+}
+
+// GetSpaceInfoResponse 是按用途和文件分类划分的空间占用，单位为字节
+type GetSpaceInfoResponse struct {
+	DriveUsedSize            uint64 `json:"drive_used_size"`
+	DriveTotalSize           uint64 `json:"drive_total_size"`
+	DefaultDriveUsedSize     uint64 `json:"default_drive_used_size"`
+	AlbumDriveUsedSize       uint64 `json:"album_drive_used_size"`
+	ShareAlbumDriveUsedSize  uint64 `json:"share_album_drive_used_size"`
+	NoteDriveUsedSize        uint64 `json:"note_drive_used_size"`
+	SboxDriveUsedSize        uint64 `json:"sbox_drive_used_size"`
+	ResourceDriveUsedSize    uint64 `json:"resource_drive_used_size"`
+	BackupDriveUsedSize      uint64 `json:"backup_drive_used_size"`
+	RecycleBinDriveUsedSize  uint64 `json:"recycle_bin_drive_used_size"`
+	CloudDriveUsedSize       uint64 `json:"cloud_drive_used_size"`
+	CloudDriveTotalSize      uint64 `json:"cloud_drive_total_size"`
+	DriveTotalSizeExceed     bool   `json:"drive_total_size_exceed"`
+	AlbumDriveTotalSizeLimit uint64 `json:"album_drive_total_size_limit"`
+
+	// 按文件分类的占用
+	VideoUsedSize  uint64 `json:"video_used_size"`
+	ImageUsedSize  uint64 `json:"image_used_size"`
+	DocUsedSize    uint64 `json:"doc_used_size"`
+	AudioUsedSize  uint64 `json:"audio_used_size"`
+	OthersUsedSize uint64 `json:"others_used_size"`
+}
+
+// CategoryUsedSize 返回按 Item.Category 划分的占用，key 为 video、image、doc、audio 和 others
+func (r *GetSpaceInfoResponse) CategoryUsedSize() map[string]uint64 {
+	return map[string]uint64{
+		"video":  r.VideoUsedSize,
+		"image":  r.ImageUsedSize,
+		"doc":    r.DocUsedSize,
+		"audio":  r.AudioUsedSize,
+		"others": r.OthersUsedSize,
+	}
+}
+
+// RemainingSize 返回剩余空间，已用空间超过总空间时返回 0
+func (r *GetSpaceInfoResponse) RemainingSize() uint64 {
+	if r.DriveUsedSize >= r.DriveTotalSize {
+		return 0
+	}
+	return r.DriveTotalSize - r.DriveUsedSize
+}
+
+func (c *Drive) DoGetSpaceInfoRequest(ctx context.Context, request GetSpaceInfoRequest) (*GetSpaceInfoResponse, error) {
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v1/user/driveCapacityDetails", Object{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(GetSpaceInfoResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// QuotaExceededError 表示上传的文件超过了剩余空间
+type QuotaExceededError struct {
+	Size          uint64
+	RemainingSize uint64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("aliyundrive: file size %v exceeds remaining quota %v", e.Size, e.RemainingSize)
+}
+
+// IsQuotaExceededError 判断是否为空间不足的错误，包括 CheckQuota 和服务端返回的错误
+func IsQuotaExceededError(err error) bool {
+	switch e := err.(type) {
+	case *QuotaExceededError:
+		return true
+	case *ErrorResponse:
+		return e.Code == "QuotaExhausted.Drive" || e.Code == "QuotaExhausted"
+	}
+	return false
+}
+
+// CheckQuota 查询剩余空间，size 超过剩余空间时返回 *QuotaExceededError
+func (c *Drive) CheckQuota(ctx context.Context, size uint64) error {
+	resp, err := c.DoGetPersonalInfoRequest(ctx, GetPersonalInfoRequest{})
+	if err != nil {
+		return err
+	}
+	if resp.PersonalSpaceInfo == nil {
+		return nil
+	}
+	remaining := resp.PersonalSpaceInfo.RemainingSize()
+	if size > remaining {
+		return &QuotaExceededError{Size: size, RemainingSize: remaining}
+	}
+	return nil
+}
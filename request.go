@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 )
 
 type Array []any
@@ -40,10 +41,17 @@ func (c *Drive) toRequest(ctx context.Context, url string, params any) (*http.Re
 		return nil, err
 	}
 	request.Header.Set("Content-Type", "application/json")
+	if c.sbox != nil {
+		request.Header.Set(sboxTokenHeader, c.sbox.token)
+	}
 	return request, nil
 }
 
 func (c *Drive) requestWithCredit(ctx context.Context, url string, params any) ([]byte, error) {
+	return c.requestWithCreditAndHeader(ctx, url, params, nil)
+}
+
+func (c *Drive) requestWithCreditAndHeader(ctx context.Context, url string, params any, header http.Header) ([]byte, error) {
 	accessToken, err := c.tokenManager.AccessToken(ctx)
 	if err != nil {
 		return nil, err
@@ -52,15 +60,25 @@ func (c *Drive) requestWithCredit(ctx context.Context, url string, params any) (
 	if err != nil {
 		return nil, err
 	}
+	for k := range header {
+		request.Header.Set(k, header.Get(k))
+	}
 	request.Header.Set("Authorization", "Bearer "+accessToken)
 	return c.doRequest(request)
 }
 
 func (c *Drive) requestWithoutCredit(ctx context.Context, url string, params any) ([]byte, error) {
+	return c.requestWithoutCreditAndHeader(ctx, url, params, nil)
+}
+
+func (c *Drive) requestWithoutCreditAndHeader(ctx context.Context, url string, params any, header http.Header) ([]byte, error) {
 	request, err := c.toRequest(ctx, url, params)
 	if err != nil {
 		return nil, err
 	}
+	for k := range header {
+		request.Header.Set(k, header.Get(k))
+	}
 	return c.doRequest(request)
 }
 
@@ -75,6 +93,14 @@ func (c *Drive) doRequest(request *http.Request) ([]byte, error) {
 		return nil, err
 	}
 
+	// 部分接口成功时没有响应体
+	if len(respData) == 0 {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return nil, &ErrorResponse{Code: strconv.Itoa(resp.StatusCode), Message: http.StatusText(resp.StatusCode)}
+		}
+		return respData, nil
+	}
+
 	result := new(ErrorResponse)
 	err = json.Unmarshal(respData, result)
 	if err != nil {
@@ -0,0 +1,215 @@
+package aliyundrive
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type Revision struct {
+	RevisionId      string    `json:"revision_id"`
+	RevisionVersion int       `json:"revision_version"`
+	DriveId         string    `json:"drive_id"`
+	FileId          string    `json:"file_id"`
+	Name            string    `json:"name"`
+	Size            uint64    `json:"size"`
+	ContentHash     string    `json:"content_hash"`
+	ContentHashName string    `json:"content_hash_name"`
+	Crc64Hash       string    `json:"crc64_hash"`
+	FileExtension   string    `json:"file_extension"`
+	IsLatestVersion bool      `json:"is_latest_version"`
+	KeepForever     bool      `json:"keep_forever"`
+	CreatorId       string    `json:"creator_id"`
+	CreatorName     string    `json:"creator_name"`
+	Thumbnail       string    `json:"thumbnail"`
+	Url             string    `json:"url"`
+	DownloadUrl     string    `json:"download_url"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type ListRevisionsRequest struct {
+	DriveId    string `json:"-"`
+	FileId     string `json:"file_id"`
+	Limit      int    `json:"limit,omitempty"`
+	NextMarker string `json:"marker,omitempty"`
+}
+
+type ListRevisionsResponse struct {
+	Items      []*Revision `json:"items"`
+	NextMarker string      `json:"next_marker"`
+}
+
+// DoListRevisionsRequest 列出文件的历史版本，按时间从新到旧排列
+func (c *Drive) DoListRevisionsRequest(ctx context.Context, request ListRevisionsRequest) (*ListRevisionsResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &struct {
+		DriveId string `json:"drive_id"`
+		ListRevisionsRequest
+	}{
+		DriveId:              driveId,
+		ListRevisionsRequest: request,
+	}
+
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/revision/list", params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(ListRevisionsResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetRevisionRequest struct {
+	DriveId    string `json:"-"`
+	FileId     string `json:"file_id"`
+	RevisionId string `json:"revision_id"`
+}
+
+type GetRevisionResponse struct {
+	Revision
+}
+
+func (c *Drive) DoGetRevisionRequest(ctx context.Context, request GetRevisionRequest) (*GetRevisionResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &struct {
+		DriveId string `json:"drive_id"`
+		GetRevisionRequest
+	}{
+		DriveId:            driveId,
+		GetRevisionRequest: request,
+	}
+
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/revision/get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(GetRevisionResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetRevisionDownloadUrlRequest struct {
+	DriveId    string `json:"-"`
+	FileId     string `json:"file_id"`
+	RevisionId string `json:"revision_id"`
+	ExpireSec  int    `json:"expire_sec,omitempty"`
+}
+
+type GetRevisionDownloadUrlResponse struct {
+	GetDownloadUrlResponse
+}
+
+func (c *Drive) DoGetRevisionDownloadUrlRequest(ctx context.Context, request GetRevisionDownloadUrlRequest) (*GetRevisionDownloadUrlResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &struct {
+		DriveId string `json:"drive_id"`
+		GetRevisionDownloadUrlRequest
+	}{
+		DriveId:                       driveId,
+		GetRevisionDownloadUrlRequest: request,
+	}
+
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/revision/get_download_url", params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(GetRevisionDownloadUrlResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type RestoreRevisionRequest struct {
+	DriveId    string `json:"-"`
+	FileId     string `json:"file_id"`
+	RevisionId string `json:"revision_id"`
+}
+
+type RestoreRevisionResponse struct {
+	Item
+}
+
+// DoRestoreRevisionRequest 把文件恢复为指定的历史版本，恢复前的内容会成为新的历史版本
+func (c *Drive) DoRestoreRevisionRequest(ctx context.Context, request RestoreRevisionRequest) (*RestoreRevisionResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &struct {
+		DriveId string `json:"drive_id"`
+		RestoreRevisionRequest
+	}{
+		DriveId:                driveId,
+		RestoreRevisionRequest: request,
+	}
+
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/revision/restore", params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(RestoreRevisionResponse)
+	if len(resp) == 0 {
+		return result, nil
+	}
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type DeleteRevisionRequest struct {
+	DriveId    string `json:"-"`
+	FileId     string `json:"file_id"`
+	RevisionId string `json:"revision_id"`
+}
+
+type DeleteRevisionResponse struct {
+}
+
+func (c *Drive) DoDeleteRevisionRequest(ctx context.Context, request DeleteRevisionRequest) (*DeleteRevisionResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &struct {
+		DriveId string `json:"drive_id"`
+		DeleteRevisionRequest
+	}{
+		DriveId:               driveId,
+		DeleteRevisionRequest: request,
+	}
+
+	_, err = c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/revision/delete", params)
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteRevisionResponse{}, nil
+}
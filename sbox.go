@@ -0,0 +1,177 @@
+package aliyundrive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const sboxTokenHeader = "X-Sbox-Token"
+
+// safeBoxRelockAttempts 是 ctx 取消后重新锁定保险箱的最多尝试次数
+const safeBoxRelockAttempts = 5
+
+var ErrSafeBoxNotUnlocked = errors.New("aliyundrive: drive is not an unlocked safe box")
+
+// safeBox 保存已解锁的保险箱的凭证，由 UnlockSafeBox 返回的 Drive 持有
+type safeBox struct {
+	token  string
+	lock   *sync.Mutex
+	locked bool
+	done   chan struct{}
+}
+
+type GetSafeBoxRequest struct {
+}
+
+type GetSafeBoxResponse struct {
+	DriveId          string `json:"drive_id"`
+	SboxUsedSize     uint64 `json:"sbox_used_size"`
+	SboxRealUsedSize uint64 `json:"sbox_real_used_size"`
+	SboxTotalSize    uint64 `json:"sbox_total_size"`
+	RecommendVip     string `json:"recommend_vip"`
+	PinSetup         bool   `json:"pin_setup"`
+	Locked           bool   `json:"locked"`
+	InsuranceEnabled bool   `json:"insurance_enabled"`
+}
+
+func (c *Drive) DoGetSafeBoxRequest(ctx context.Context, request GetSafeBoxRequest) (*GetSafeBoxResponse, error) {
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/sbox/get", Object{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(GetSafeBoxResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type UnlockSafeBoxRequest struct {
+	Pin string `json:"pin"`
+}
+
+type UnlockSafeBoxResponse struct {
+	DriveId    string    `json:"drive_id"`
+	Token      string    `json:"token"`
+	ExpireTime time.Time `json:"expire_time"`
+}
+
+func (c *Drive) DoUnlockSafeBoxRequest(ctx context.Context, request UnlockSafeBoxRequest) (*UnlockSafeBoxResponse, error) {
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/sbox/unlock", request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(UnlockSafeBoxResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type LockSafeBoxRequest struct {
+	Token string `json:"-"`
+}
+
+type LockSafeBoxResponse struct {
+}
+
+func (c *Drive) DoLockSafeBoxRequest(ctx context.Context, request LockSafeBoxRequest) (*LockSafeBoxResponse, error) {
+	header := make(http.Header)
+	header.Set(sboxTokenHeader, request.Token)
+	_, err := c.requestWithCreditAndHeader(ctx, "https://api.aliyundrive.com/v2/sbox/lock", Object{}, header)
+	if err != nil {
+		return nil, err
+	}
+	return &LockSafeBoxResponse{}, nil
+}
+
+// UnlockSafeBox 使用 pin 解锁保险箱，返回的 Drive 默认使用保险箱的 drive id，
+// 所有请求都会带上保险箱的凭证，可以直接用于列表、上传和下载。
+// ctx 取消时自动重新锁定保险箱，失败时逐渐延长间隔重试，最终失败时记录到 WithErrorLog 指定的 logger。
+// 也可以调用返回的 Drive 的 LockSafeBox 提前锁定。
+// 等待 ctx 的 goroutine 在 ctx 取消或 LockSafeBox 成功后退出，ctx 永远不会取消时
+// 必须调用 LockSafeBox，否则保险箱不会重新锁定，goroutine 也不会退出。
+func (c *Drive) UnlockSafeBox(ctx context.Context, pin string) (*Drive, error) {
+	resp, err := c.DoUnlockSafeBoxRequest(ctx, UnlockSafeBoxRequest{Pin: pin})
+	if err != nil {
+		return nil, err
+	}
+
+	box := &Drive{
+		driveId:      resp.DriveId,
+		tokenManager: c.tokenManager,
+		httpClient:   c.httpClient,
+		lock:         new(sync.Mutex),
+		errorLog:     c.errorLog,
+		sbox: &safeBox{
+			token: resp.Token,
+			lock:  new(sync.Mutex),
+			done:  make(chan struct{}),
+		},
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			box.relockSafeBox()
+		case <-box.sbox.done:
+		}
+	}()
+	return box, nil
+}
+
+// relockSafeBox 在 ctx 取消后重新锁定保险箱，失败时间隔 1s、2s、4s... 重试
+func (c *Drive) relockSafeBox() {
+	interval := time.Second
+	var err error
+	for i := 0; i < safeBoxRelockAttempts; i++ {
+		if i > 0 {
+			timer := time.NewTimer(interval)
+			select {
+			case <-timer.C:
+			case <-c.sbox.done:
+				// 已经通过 LockSafeBox 锁定
+				timer.Stop()
+				return
+			}
+			interval *= 2
+		}
+
+		// ctx 已经取消，使用新的 context 发送锁定请求
+		lockCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err = c.LockSafeBox(lockCtx)
+		cancel()
+		if err == nil {
+			return
+		}
+	}
+	c.errorLog.Printf("aliyundrive: failed to lock safe box after %v attempts: %v", safeBoxRelockAttempts, err)
+}
+
+// LockSafeBox 锁定由 UnlockSafeBox 返回的 Drive 对应的保险箱。
+// 锁定成功后重复调用直接返回 nil，失败时可以再次调用重试。
+func (c *Drive) LockSafeBox(ctx context.Context) error {
+	if c.sbox == nil {
+		return ErrSafeBoxNotUnlocked
+	}
+	c.sbox.lock.Lock()
+	defer c.sbox.lock.Unlock()
+
+	if c.sbox.locked {
+		return nil
+	}
+	_, err := c.DoLockSafeBoxRequest(ctx, LockSafeBoxRequest{Token: c.sbox.token})
+	if err != nil {
+		return err
+	}
+	c.sbox.locked = true
+	close(c.sbox.done)
+	return nil
+}
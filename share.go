@@ -0,0 +1,175 @@
+package aliyundrive
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type ShareLink struct {
+	ShareId       string    `json:"share_id"`
+	ShareName     string    `json:"share_name"`
+	ShareUrl      string    `json:"share_url"`
+	SharePwd      string    `json:"share_pwd"`
+	DriveId       string    `json:"drive_id"`
+	FileIdList    []string  `json:"file_id_list"`
+	Creator       string    `json:"creator"`
+	Description   string    `json:"description"`
+	Expiration    string    `json:"expiration"`
+	Expired       bool      `json:"expired"`
+	Status        string    `json:"status"`
+	PreviewCount  uint64    `json:"preview_count"`
+	DownloadCount uint64    `json:"download_count"`
+	SaveCount     uint64    `json:"save_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ExpiresAt 返回分享的过期时间，永久有效的分享返回 false
+func (l *ShareLink) ExpiresAt() (time.Time, bool) {
+	if l.Expiration == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, l.Expiration)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// formatExpiration 零值表示永久有效
+func formatExpiration(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+type CreateShareLinkRequest struct {
+	DriveId    string    `json:"-"`
+	FileIdList []string  `json:"file_id_list"`
+	SharePwd   string    `json:"share_pwd"`
+	Expiration time.Time `json:"-"`
+}
+
+type CreateShareLinkResponse struct {
+	ShareLink
+}
+
+func (c *Drive) DoCreateShareLinkRequest(ctx context.Context, request CreateShareLinkRequest) (*CreateShareLinkResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &struct {
+		DriveId    string `json:"drive_id"`
+		Expiration string `json:"expiration"`
+		CreateShareLinkRequest
+	}{
+		DriveId:                driveId,
+		Expiration:             formatExpiration(request.Expiration),
+		CreateShareLinkRequest: request,
+	}
+
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v2/share_link/create", params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(CreateShareLinkResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type ListShareLinksRequest struct {
+	Creator          string `json:"creator"`
+	IncludeCancelled bool   `json:"include_cancelled"`
+	OrderBy          string `json:"order_by,omitempty"`
+	OrderDirection   string `json:"order_direction,omitempty"`
+	Limit            int    `json:"limit,omitempty"`
+	NextMarker       string `json:"marker,omitempty"`
+}
+
+type ListShareLinksResponse struct {
+	Items      []*ShareLink `json:"items"`
+	NextMarker string       `json:"next_marker"`
+}
+
+// DoListShareLinksRequest 列出 Creator 创建的分享，Creator 为空时使用当前用户
+func (c *Drive) DoListShareLinksRequest(ctx context.Context, request ListShareLinksRequest) (*ListShareLinksResponse, error) {
+	if request.Creator == "" {
+		userId, err := c.resolveUserId(ctx)
+		if err != nil {
+			return nil, err
+		}
+		request.Creator = userId
+	}
+
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v3/share_link/list", request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(ListShareLinksResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type UpdateShareLinkRequest struct {
+	ShareId     string     `json:"share_id"`
+	SharePwd    *string    `json:"share_pwd,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Expiration  *time.Time `json:"-"`
+}
+
+type UpdateShareLinkResponse struct {
+	ShareLink
+}
+
+// DoUpdateShareLinkRequest 只更新非 nil 的字段，Expiration 指向零值时改为永久有效
+func (c *Drive) DoUpdateShareLinkRequest(ctx context.Context, request UpdateShareLinkRequest) (*UpdateShareLinkResponse, error) {
+	params := &struct {
+		Expiration *string `json:"expiration,omitempty"`
+		UpdateShareLinkRequest
+	}{
+		UpdateShareLinkRequest: request,
+	}
+	if request.Expiration != nil {
+		expiration := formatExpiration(*request.Expiration)
+		params.Expiration = &expiration
+	}
+
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v2/share_link/update", params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(UpdateShareLinkResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type CancelShareLinkRequest struct {
+	ShareId string `json:"share_id"`
+}
+
+type CancelShareLinkResponse struct {
+}
+
+func (c *Drive) DoCancelShareLinkRequest(ctx context.Context, request CancelShareLinkRequest) (*CancelShareLinkResponse, error) {
+	_, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v2/share_link/cancel", request)
+	if err != nil {
+		return nil, err
+	}
+	return &CancelShareLinkResponse{}, nil
+}
@@ -0,0 +1,183 @@
+package aliyundrive
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type GetShareTokenRequest struct {
+	ShareId  string `json:"share_id"`
+	SharePwd string `json:"share_pwd"`
+}
+
+type GetShareTokenResponse struct {
+	ShareToken string    `json:"share_token"`
+	ExpireTime time.Time `json:"expire_time"`
+	ExpiresIn  int64     `json:"expires_in"`
+}
+
+func (c *Drive) DoGetShareTokenRequest(ctx context.Context, request GetShareTokenRequest) (*GetShareTokenResponse, error) {
+	resp, err := c.requestWithoutCredit(ctx, "https://api.aliyundrive.com/v2/share_link/get_share_token", request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(GetShareTokenResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type ListByShareRequest struct {
+	ShareToken     string `json:"-"`
+	ShareId        string `json:"share_id"`
+	ParentFileId   string `json:"parent_file_id,omitempty"`
+	OrderBy        string `json:"order_by,omitempty"`
+	OrderDirection string `json:"order_direction,omitempty"`
+	Limit          int    `json:"limit,omitempty"`
+	NextMarker     string `json:"marker,omitempty"`
+}
+
+type ListByShareResponse struct {
+	Items      []*Item `json:"items"`
+	NextMarker string  `json:"next_marker"`
+}
+
+func (c *Drive) DoListByShareRequest(ctx context.Context, request ListByShareRequest) (*ListByShareResponse, error) {
+	header := make(http.Header)
+	header.Set("X-Share-Token", request.ShareToken)
+	resp, err := c.requestWithoutCreditAndHeader(ctx, "https://api.aliyundrive.com/adrive/v2/file/list_by_share", request, header)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(ListByShareResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetShareDownloadUrlRequest struct {
+	ShareToken string `json:"-"`
+	ShareId    string `json:"share_id"`
+	FileId     string `json:"file_id"`
+	ExpireSec  int    `json:"expire_sec,omitempty"`
+}
+
+type GetShareDownloadUrlResponse struct {
+	DownloadUrl string    `json:"download_url"`
+	Url         string    `json:"url"`
+	Expiration  time.Time `json:"expiration"`
+}
+
+// DoGetShareDownloadUrlRequest 获取分享中文件的下载链接，需要登录
+func (c *Drive) DoGetShareDownloadUrlRequest(ctx context.Context, request GetShareDownloadUrlRequest) (*GetShareDownloadUrlResponse, error) {
+	header := make(http.Header)
+	header.Set("X-Share-Token", request.ShareToken)
+	resp, err := c.requestWithCreditAndHeader(ctx, "https://api.aliyundrive.com/v2/file/get_share_link_download_url", request, header)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(GetShareDownloadUrlResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type SaveShareFilesRequest struct {
+	ShareToken     string
+	ShareId        string
+	FileIdList     []string
+	ToDriveId      string
+	ToParentFileId string
+	AutoRename     bool
+}
+
+// SaveShareFiles 把分享中的文件或文件夹保存到自己的网盘，按 FileIdList 的顺序返回每个文件的结果。
+// 保存文件夹是异步任务，会等待所有任务完成，任务失败时对应结果的 Err 为 *AsyncTaskError。
+func (c *Drive) SaveShareFiles(ctx context.Context, request SaveShareFilesRequest) ([]*BatchResult[CopyResponse], error) {
+	toDriveId, err := c.resolveDriveId(ctx, request.ToDriveId)
+	if err != nil {
+		return nil, err
+	}
+
+	subs := make([]*BatchSubRequest, len(request.FileIdList))
+	for i, fileId := range request.FileIdList {
+		subs[i] = &BatchSubRequest{
+			Url: "/file/copy",
+			Body: Object{
+				"share_id":          request.ShareId,
+				"file_id":           fileId,
+				"to_drive_id":       toDriveId,
+				"to_parent_file_id": request.ToParentFileId,
+				"auto_rename":       request.AutoRename,
+			},
+		}
+	}
+	results, err := doBatch[CopyResponse](ctx, c, BatchRequest{Requests: subs, ShareToken: request.ShareToken})
+	if err != nil {
+		return results, err
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		_, result.Err = c.WaitTask(ctx, result.Response.AsyncTaskId)
+	}
+	return results, nil
+}
+
+type ShareTokenManager interface {
+	ShareToken(ctx context.Context) (string, error)
+}
+
+type shareTokenManager struct {
+	drive      *Drive
+	shareId    string
+	sharePwd   string
+	shareToken string
+	expireTime time.Time
+	lock       *sync.Mutex
+}
+
+// NewShareTokenManager 创建自动刷新的 share token 管理器
+func NewShareTokenManager(drive *Drive, shareId string, sharePwd string) *shareTokenManager {
+	return &shareTokenManager{
+		drive:      drive,
+		shareId:    shareId,
+		sharePwd:   sharePwd,
+		expireTime: time.Unix(0, 0),
+		lock:       new(sync.Mutex),
+	}
+}
+
+func (m *shareTokenManager) ShareToken(ctx context.Context) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now()
+	if now.Before(m.expireTime) {
+		return m.shareToken, nil
+	}
+
+	resp, err := m.drive.DoGetShareTokenRequest(ctx, GetShareTokenRequest{
+		ShareId:  m.shareId,
+		SharePwd: m.sharePwd,
+	})
+	if err != nil {
+		return "", err
+	}
+	m.shareToken = resp.ShareToken
+	m.expireTime = now.Add(time.Second * time.Duration(resp.ExpiresIn-60))
+	return m.shareToken, nil
+}
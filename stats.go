@@ -0,0 +1,239 @@
+package aliyundrive
+
+import (
+	"context"
+	"path"
+	"sort"
+	"sync"
+)
+
+const DefaultTreeStatsConcurrency = 4
+const DefaultTreeStatsTopN = 10
+
+type TreeStatsRequest struct {
+	DriveId string
+	// FileId 为统计的文件夹，为空时统计根目录
+	FileId string
+	// MaxDepth 超过该深度的文件夹只通过 DoGetFolderSizeInfoRequest 获取总量，0 表示不限制
+	MaxDepth int
+	// Concurrency 同时遍历文件夹的 worker 数量，默认为 DefaultTreeStatsConcurrency
+	Concurrency int
+	// TopN 每个文件夹保留的最大文件数量，默认为 DefaultTreeStatsTopN
+	TopN int
+}
+
+type CountSize struct {
+	Count uint64
+	Size  uint64
+}
+
+// DirStats 是文件夹及其所有子文件夹的统计结果
+type DirStats struct {
+	FileId string
+	Name   string
+	// Path 以统计的文件夹名开头，统计根目录时为以 / 开头的绝对路径
+	Path        string
+	Depth       int
+	Size        uint64
+	FileCount   uint64
+	FolderCount uint64
+	// Summarized 为 true 时只有总量，没有 Largest、ByCategory、ByExtension 和 Children，
+	// 这部分也不计入上层文件夹的 Largest、ByCategory 和 ByExtension
+	Summarized  bool
+	Largest     []*Item
+	ByCategory  map[string]*CountSize
+	ByExtension map[string]*CountSize
+	// Children 为子文件夹，按 Size 从大到小排列
+	Children []*DirStats
+
+	// files 为直接位于该文件夹中的文件，汇总后清空
+	files []*Item
+}
+
+func addCountSize(counts map[string]*CountSize, key string, count uint64, size uint64) {
+	cs, ok := counts[key]
+	if !ok {
+		cs = new(CountSize)
+		counts[key] = cs
+	}
+	cs.Count += count
+	cs.Size += size
+}
+
+// treeWalker 使用固定数量的 worker 从队列中取出文件夹列出内容，子文件夹加入队列，
+// 全部列出后再自底向上汇总
+type treeWalker struct {
+	c       *Drive
+	driveId string
+	request TreeStatsRequest
+	cancel  context.CancelFunc
+
+	lock    *sync.Mutex
+	cond    *sync.Cond
+	queue   []*DirStats
+	pending int
+	err     error
+}
+
+// TreeStats 遍历文件夹，统计每个子文件夹的大小、文件数量、最大的文件以及按分类和扩展名的分布
+func (c *Drive) TreeStats(ctx context.Context, request TreeStatsRequest) (*DirStats, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+	if request.FileId == "" {
+		request.FileId = RootFileId
+	}
+	if request.Concurrency <= 0 {
+		request.Concurrency = DefaultTreeStatsConcurrency
+	}
+	if request.TopN <= 0 {
+		request.TopN = DefaultTreeStatsTopN
+	}
+
+	root := &DirStats{FileId: request.FileId, Name: "/", Path: "/"}
+	if request.FileId != RootFileId {
+		getResp, err := c.DoGetRequest(ctx, GetRequest{DriveId: driveId, FileId: request.FileId})
+		if err != nil {
+			return nil, err
+		}
+		root.Name = getResp.Name
+		root.Path = getResp.Name
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	lock := new(sync.Mutex)
+	w := &treeWalker{
+		c:       c,
+		driveId: driveId,
+		request: request,
+		cancel:  cancel,
+		lock:    lock,
+		cond:    sync.NewCond(lock),
+		queue:   []*DirStats{root},
+		pending: 1,
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < request.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.work(ctx)
+		}()
+	}
+	wg.Wait()
+	if w.err != nil {
+		return nil, w.err
+	}
+
+	w.aggregate(root)
+	return root, nil
+}
+
+func (w *treeWalker) work(ctx context.Context) {
+	for {
+		w.lock.Lock()
+		for len(w.queue) == 0 && w.pending > 0 && w.err == nil {
+			w.cond.Wait()
+		}
+		if len(w.queue) == 0 || w.err != nil {
+			w.lock.Unlock()
+			return
+		}
+		dir := w.queue[len(w.queue)-1]
+		w.queue = w.queue[:len(w.queue)-1]
+		w.lock.Unlock()
+
+		err := w.visit(ctx, dir)
+
+		w.lock.Lock()
+		if err != nil && w.err == nil {
+			w.err = err
+			w.cancel()
+		}
+		if err == nil {
+			w.queue = append(w.queue, dir.Children...)
+			w.pending += len(dir.Children)
+		}
+		w.pending--
+		w.cond.Broadcast()
+		w.lock.Unlock()
+	}
+}
+
+// visit 列出 dir 的内容，超过 MaxDepth 时使用服务端的统计结果
+func (w *treeWalker) visit(ctx context.Context, dir *DirStats) error {
+	if w.request.MaxDepth > 0 && dir.Depth > w.request.MaxDepth {
+		resp, err := w.c.DoGetFolderSizeInfoRequest(ctx, GetFolderSizeInfoRequest{DriveId: w.driveId, FileId: dir.FileId})
+		if err != nil {
+			return err
+		}
+		dir.Size = resp.Size
+		dir.FileCount = resp.FileCount
+		dir.FolderCount = resp.FolderCount
+		dir.Summarized = true
+		return nil
+	}
+
+	return w.c.NewListPager(ListRequest{
+		DriveId:      w.driveId,
+		ParentFileId: dir.FileId,
+		Limit:        LimitMax,
+	}).ForEach(ctx, func(item *Item) error {
+		if item.Type == "folder" {
+			dir.Children = append(dir.Children, &DirStats{
+				FileId: item.FileId,
+				Name:   item.Name,
+				Path:   path.Join(dir.Path, item.Name),
+				Depth:  dir.Depth + 1,
+			})
+		} else {
+			dir.files = append(dir.files, item)
+		}
+		return nil
+	})
+}
+
+// aggregate 自底向上汇总子文件夹的统计结果
+func (w *treeWalker) aggregate(dir *DirStats) {
+	if dir.Summarized {
+		return
+	}
+
+	dir.ByCategory = make(map[string]*CountSize)
+	dir.ByExtension = make(map[string]*CountSize)
+	files := dir.files
+	dir.files = nil
+	for _, item := range files {
+		dir.Size += item.Size
+		dir.FileCount++
+		addCountSize(dir.ByCategory, item.Category, 1, item.Size)
+		addCountSize(dir.ByExtension, item.FileExtension, 1, item.Size)
+	}
+
+	for _, child := range dir.Children {
+		w.aggregate(child)
+		dir.Size += child.Size
+		dir.FileCount += child.FileCount
+		dir.FolderCount += child.FolderCount + 1
+		files = append(files, child.Largest...)
+		for k, cs := range child.ByCategory {
+			addCountSize(dir.ByCategory, k, cs.Count, cs.Size)
+		}
+		for k, cs := range child.ByExtension {
+			addCountSize(dir.ByExtension, k, cs.Count, cs.Size)
+		}
+	}
+	sort.Slice(dir.Children, func(i, j int) bool {
+		return dir.Children[i].Size > dir.Children[j].Size
+	})
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Size > files[j].Size
+	})
+	if len(files) > w.request.TopN {
+		files = files[:w.request.TopN:w.request.TopN]
+	}
+	dir.Largest = files
+}
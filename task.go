@@ -0,0 +1,114 @@
+package aliyundrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const AsyncTaskStateRunning = "Running"
+const AsyncTaskStateSucceed = "Succeed"
+const AsyncTaskStateFailed = "Failed"
+const AsyncTaskStatePartialSucceed = "PartialSucceed"
+
+type GetAsyncTaskRequest struct {
+	AsyncTaskId string `json:"async_task_id"`
+}
+
+type GetAsyncTaskResponse struct {
+	AsyncTaskId       string `json:"async_task_id"`
+	State             string `json:"state"`
+	ConsumedProcess   uint64 `json:"consumed_process"`
+	TotalProcess      uint64 `json:"total_process"`
+	PunishedFileCount uint64 `json:"punished_file_count"`
+	ErrCode           int    `json:"err_code"`
+	ErrMessage        string `json:"err_message"`
+}
+
+// AsyncTaskError 表示异步任务失败或只有部分成功
+type AsyncTaskError struct {
+	AsyncTaskId       string
+	State             string
+	ErrCode           int
+	ErrMessage        string
+	PunishedFileCount uint64
+}
+
+func (e *AsyncTaskError) Error() string {
+	return fmt.Sprintf("aliyundrive: async task %v %v: %v %v", e.AsyncTaskId, e.State, e.ErrCode, e.ErrMessage)
+}
+
+func IsAsyncTaskError(err error) bool {
+	_, ok := err.(*AsyncTaskError)
+	return ok
+}
+
+// Done 判断任务是否已经结束
+func (r *GetAsyncTaskResponse) Done() bool {
+	switch r.State {
+	case AsyncTaskStateSucceed, AsyncTaskStateFailed, AsyncTaskStatePartialSucceed:
+		return true
+	}
+	return false
+}
+
+// Err 返回失败或部分成功的任务的错误，其他状态返回 nil
+func (r *GetAsyncTaskResponse) Err() error {
+	if r.State != AsyncTaskStateFailed && r.State != AsyncTaskStatePartialSucceed {
+		return nil
+	}
+	return &AsyncTaskError{
+		AsyncTaskId:       r.AsyncTaskId,
+		State:             r.State,
+		ErrCode:           r.ErrCode,
+		ErrMessage:        r.ErrMessage,
+		PunishedFileCount: r.PunishedFileCount,
+	}
+}
+
+func (c *Drive) DoGetAsyncTaskRequest(ctx context.Context, request GetAsyncTaskRequest) (*GetAsyncTaskResponse, error) {
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/async_task/get", request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(GetAsyncTaskResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WaitTask 轮询异步任务直到成功或失败，排队中、未知或空的状态都会继续轮询，
+// 轮询间隔从 200ms 开始逐渐增加到 5s。任务失败或部分成功时返回 *AsyncTaskError。
+// id 为空时表示操作已同步完成，直接返回。
+func (c *Drive) WaitTask(ctx context.Context, id string) (*GetAsyncTaskResponse, error) {
+	if id == "" {
+		return &GetAsyncTaskResponse{State: AsyncTaskStateSucceed}, nil
+	}
+
+	interval := 200 * time.Millisecond
+	for {
+		resp, err := c.DoGetAsyncTaskRequest(ctx, GetAsyncTaskRequest{AsyncTaskId: id})
+		if err != nil {
+			return nil, err
+		}
+		if resp.Done() {
+			return resp, resp.Err()
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, ctx.Err()
+		}
+		interval *= 2
+		if interval > 5*time.Second {
+			interval = 5 * time.Second
+		}
+	}
+}
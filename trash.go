@@ -0,0 +1,191 @@
+package aliyundrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrPurgeTrashNoPolicy = errors.New("aliyundrive: purge trash requires OlderThan > 0 or All")
+
+// RestoreVerifyError 表示文件已经恢复，但没有回到放入回收站前的位置，
+// 或者所在的某一级上级文件夹仍在回收站中
+type RestoreVerifyError struct {
+	FileId               string
+	ExpectedParentFileId string
+	ParentFileId         string
+	Trashed              bool
+	TrashedAncestorId    string
+}
+
+func (e *RestoreVerifyError) Error() string {
+	if e.Trashed {
+		return fmt.Sprintf("aliyundrive: file %v is still in trash after restore", e.FileId)
+	}
+	if e.TrashedAncestorId != "" {
+		return fmt.Sprintf("aliyundrive: file %v restored but ancestor %v is still in trash", e.FileId, e.TrashedAncestorId)
+	}
+	return fmt.Sprintf("aliyundrive: file %v restored to %v, expected %v", e.FileId, e.ParentFileId, e.ExpectedParentFileId)
+}
+
+func IsRestoreVerifyError(err error) bool {
+	_, ok := err.(*RestoreVerifyError)
+	return ok
+}
+
+// RestoreAndVerify 恢复文件并确认文件回到了原来的文件夹，且所有上级文件夹都不在回收站中，
+// 否则返回恢复后的文件和 *RestoreVerifyError
+func (c *Drive) RestoreAndVerify(ctx context.Context, request RestoreRequest) (*Item, error) {
+	before, err := c.DoGetRequest(ctx, GetRequest{DriveId: request.DriveId, FileId: request.FileId})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.DoRestoreRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := c.DoGetRequest(ctx, GetRequest{DriveId: request.DriveId, FileId: request.FileId})
+	if err != nil {
+		return nil, err
+	}
+	if after.Trashed || after.ParentFileId != before.ParentFileId {
+		return &after.Item, &RestoreVerifyError{
+			FileId:               request.FileId,
+			ExpectedParentFileId: before.ParentFileId,
+			ParentFileId:         after.ParentFileId,
+			Trashed:              after.Trashed,
+		}
+	}
+
+	parentFileId := after.ParentFileId
+	for parentFileId != "" && parentFileId != RootFileId {
+		parent, err := c.DoGetRequest(ctx, GetRequest{DriveId: request.DriveId, FileId: parentFileId})
+		if err != nil {
+			return nil, err
+		}
+		if parent.Trashed {
+			return &after.Item, &RestoreVerifyError{
+				FileId:               request.FileId,
+				ExpectedParentFileId: before.ParentFileId,
+				ParentFileId:         after.ParentFileId,
+				TrashedAncestorId:    parent.FileId,
+			}
+		}
+		parentFileId = parent.ParentFileId
+	}
+	return &after.Item, nil
+}
+
+// TrashedBefore 匹配在 t 之前放入回收站的文件
+func TrashedBefore(t time.Time) ItemPredicate {
+	return func(item *Item) bool {
+		return !item.TrashedAt.IsZero() && item.TrashedAt.Before(t)
+	}
+}
+
+// ExpiresBefore 匹配在 t 之前会被回收站自动清除的文件
+func ExpiresBefore(t time.Time) ItemPredicate {
+	return func(item *Item) bool {
+		return !item.GMTExpired.IsZero() && item.GMTExpired.Before(t)
+	}
+}
+
+// FindTrash 遍历回收站，返回满足全部 predicates 的文件
+func (c *Drive) FindTrash(ctx context.Context, driveId string, predicates ...ItemPredicate) (ItemQuery, error) {
+	var items ItemQuery
+	err := c.NewTrashPager(ListTrashRequest{DriveId: driveId, Limit: LimitMax}).ForEach(ctx, func(item *Item) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items.Filter(predicates...), nil
+}
+
+// DeleteTrashed 彻底删除回收站中的文件，按 items 的顺序返回每个文件的结果
+func (c *Drive) DeleteTrashed(ctx context.Context, driveId string, items []*Item) ([]*BatchResult[DeleteResponse], error) {
+	requests := make([]DeleteRequest, len(items))
+	for i, item := range items {
+		requests[i] = DeleteRequest{DriveId: driveId, FileId: item.FileId}
+	}
+	return c.BatchDelete(ctx, requests)
+}
+
+type PurgeTrashRequest struct {
+	DriveId string
+	// OlderThan 彻底删除放入回收站超过该时长的文件
+	OlderThan time.Duration
+	// All 为 true 时忽略 OlderThan，删除所有满足 Predicates 的文件
+	All bool
+	// Predicates 额外的过滤条件，与 OlderThan 同时满足的文件才会被删除
+	Predicates []ItemPredicate
+	// DryRun 只返回会被删除的文件，不实际删除
+	DryRun bool
+}
+
+type PurgeTrashResponse struct {
+	Items   []*Item
+	Results []*BatchResult[DeleteResponse]
+}
+
+// PurgeTrash 彻底删除回收站中放入时间超过 OlderThan 的文件，OlderThan 不大于 0 且 All 为 false 时
+// 返回 ErrPurgeTrashNoPolicy。先遍历完整个回收站再删除，避免删除过程中分页结果发生变化。
+func (c *Drive) PurgeTrash(ctx context.Context, request PurgeTrashRequest) (*PurgeTrashResponse, error) {
+	predicates := request.Predicates
+	if !request.All {
+		if request.OlderThan <= 0 {
+			return nil, ErrPurgeTrashNoPolicy
+		}
+		predicates = append([]ItemPredicate{TrashedBefore(time.Now().Add(-request.OlderThan))}, predicates...)
+	}
+	items, err := c.FindTrash(ctx, request.DriveId, predicates...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PurgeTrashResponse{Items: items}
+	if request.DryRun || len(items) == 0 {
+		return result, nil
+	}
+	result.Results, err = c.DeleteTrashed(ctx, request.DriveId, items)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+type RestoreTrashRequest struct {
+	DriveId    string
+	Predicates []ItemPredicate
+}
+
+type RestoreTrashResponse struct {
+	Items   []*Item
+	Results []*BatchResult[RestoreResponse]
+}
+
+// RestoreTrash 恢复回收站中满足全部 Predicates 的文件，Predicates 为空时恢复所有文件
+func (c *Drive) RestoreTrash(ctx context.Context, request RestoreTrashRequest) (*RestoreTrashResponse, error) {
+	items, err := c.FindTrash(ctx, request.DriveId, request.Predicates...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RestoreTrashResponse{Items: items}
+	if len(items) == 0 {
+		return result, nil
+	}
+	requests := make([]RestoreRequest, len(items))
+	for i, item := range items {
+		requests[i] = RestoreRequest{DriveId: request.DriveId, FileId: item.FileId}
+	}
+	result.Results, err = c.BatchRestore(ctx, requests)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package aliyundrive
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+const DefaultChunkSize = 10 * MB
+
+type UploadRequest struct {
+	DriveId      string
+	Name         string
+	ParentFileId string
+	Size         uint64
+	ChunkSize    uint64
+	Reader       io.Reader
+	// CheckQuota 为 true 时先确认剩余空间足够，不足时返回 *QuotaExceededError
+	CheckQuota bool
+}
+
+type UploadResponse struct {
+	Item
+}
+
+// Upload 创建文件并按分片顺序上传 Reader 中的 Size 字节数据
+func (c *Drive) Upload(ctx context.Context, request UploadRequest) (*UploadResponse, error) {
+	chunkSize := request.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if request.CheckQuota {
+		err := c.CheckQuota(ctx, request.Size)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	createResp, err := c.DoCreateFileRequest(ctx, CreateFileRequest{
+		DriveId:      request.DriveId,
+		Name:         request.Name,
+		ParentFileId: request.ParentFileId,
+		Size:         request.Size,
+		ChunkSize:    chunkSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !createResp.RapidUpload {
+		err = c.uploadParts(ctx, createResp.PartInfoList, request.Reader, request.Size, chunkSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	completeResp, err := c.DoCompleteUploadFileRequest(ctx, CompleteUploadFileRequest{
+		DriveId:  request.DriveId,
+		FileId:   createResp.FileId,
+		UploadId: createResp.UploadId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UploadResponse{Item: completeResp.Item}, nil
+}
+
+// uploadParts 把 r 中的 size 字节按 chunkSize 分片依次上传到 parts
+func (c *Drive) uploadParts(ctx context.Context, parts []*PartInfo, r io.Reader, size uint64, chunkSize uint64) error {
+	remain := size
+	buf := make([]byte, chunkSize)
+	for _, part := range parts {
+		n := chunkSize
+		if remain < n {
+			n = remain
+		}
+		_, err := io.ReadFull(r, buf[:n])
+		if err != nil {
+			return err
+		}
+		_, err = c.DoUploadFileRequest(ctx, UploadFileRequest{
+			Url:  part.UploadUrl,
+			File: bytes.NewReader(buf[:n]),
+		})
+		if err != nil {
+			return err
+		}
+		remain -= n
+	}
+	return nil
+}
@@ -6,21 +6,23 @@ import (
 )
 
 type GetUserInfoResponse struct {
-	DomainID       string `json:"domain_id"`
-	UserID         string `json:"user_id"`
-	Avatar         string `json:"avatar"`
-	CreatedAt      int64  `json:"created_at"`
-	UpdatedAt      int64  `json:"updated_at"`
-	Email          string `json:"email"`
-	NickName       string `json:"nick_name"`
-	Phone          string `json:"phone"`
-	PhoneRegion    string `json:"phone_region"`
-	Role           string `json:"role"`
-	Status         string `json:"status"`
-	UserName       string `json:"user_name"`
-	Description    string `json:"description"`
-	DefaultDriveID string `json:"default_drive_id"`
-	UserData       struct {
+	DomainID        string `json:"domain_id"`
+	UserID          string `json:"user_id"`
+	Avatar          string `json:"avatar"`
+	CreatedAt       int64  `json:"created_at"`
+	UpdatedAt       int64  `json:"updated_at"`
+	Email           string `json:"email"`
+	NickName        string `json:"nick_name"`
+	Phone           string `json:"phone"`
+	PhoneRegion     string `json:"phone_region"`
+	Role            string `json:"role"`
+	Status          string `json:"status"`
+	UserName        string `json:"user_name"`
+	Description     string `json:"description"`
+	DefaultDriveID  string `json:"default_drive_id"`
+	ResourceDriveID string `json:"resource_drive_id"`
+	BackupDriveID   string `json:"backup_drive_id"`
+	UserData        struct {
 	} `json:"user_data"`
 	DenyChangePasswordBySelf    bool        `json:"deny_change_password_by_self"`
 	NeedChangePasswordNextLogin bool        `json:"need_change_password_next_login"`
@@ -44,3 +46,62 @@ func (c *Drive) DoGetUserInfoRequest(ctx context.Context) (*GetUserInfoResponse,
 	}
 	return result, nil
 }
+
+type GetAdriveUserInfoResponse struct {
+	UserID          string `json:"user_id"`
+	DefaultDriveID  string `json:"default_drive_id"`
+	ResourceDriveID string `json:"resource_drive_id"`
+	BackupDriveID   string `json:"backup_drive_id"`
+	SboxDriveID     string `json:"sbox_drive_id"`
+}
+
+func (c *Drive) DoGetAdriveUserInfoRequest(ctx context.Context) (*GetAdriveUserInfoResponse, error) {
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/adrive/v1/user/get", Object{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(GetAdriveUserInfoResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetDrivesResponse struct {
+	DefaultDriveID  string
+	ResourceDriveID string
+	BackupDriveID   string
+}
+
+// GetDrives 查询账号下的所有网盘，/v2/user/get 中缺少的网盘再从 /adrive/v1/user/get 中补全
+func (c *Drive) GetDrives(ctx context.Context) (*GetDrivesResponse, error) {
+	userInfo, err := c.DoGetUserInfoRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &GetDrivesResponse{
+		DefaultDriveID:  userInfo.DefaultDriveID,
+		ResourceDriveID: userInfo.ResourceDriveID,
+		BackupDriveID:   userInfo.BackupDriveID,
+	}
+	if result.ResourceDriveID != "" && result.BackupDriveID != "" {
+		return result, nil
+	}
+
+	adriveUserInfo, err := c.DoGetAdriveUserInfoRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if result.DefaultDriveID == "" {
+		result.DefaultDriveID = adriveUserInfo.DefaultDriveID
+	}
+	if result.ResourceDriveID == "" {
+		result.ResourceDriveID = adriveUserInfo.ResourceDriveID
+	}
+	if result.BackupDriveID == "" {
+		result.BackupDriveID = adriveUserInfo.BackupDriveID
+	}
+	return result, nil
+}
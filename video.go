@@ -0,0 +1,109 @@
+package aliyundrive
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const VideoTemplateLD = "LD"
+const VideoTemplateSD = "SD"
+const VideoTemplateHD = "HD"
+const VideoTemplateFHD = "FHD"
+const VideoTemplateQHD = "QHD"
+
+const TranscodingStatusFinished = "finished"
+
+type LiveTranscodingTask struct {
+	TemplateId             string `json:"template_id"`
+	TemplateName           string `json:"template_name"`
+	TemplateWidth          int    `json:"template_width"`
+	TemplateHeight         int    `json:"template_height"`
+	Status                 string `json:"status"`
+	Stage                  string `json:"stage"`
+	Url                    string `json:"url"`
+	KeepOriginalResolution bool   `json:"keep_original_resolution"`
+}
+
+type LiveTranscodingSubtitleTask struct {
+	Language string `json:"language"`
+	Status   string `json:"status"`
+	Url      string `json:"url"`
+}
+
+type VideoPreviewPlayInfo struct {
+	Category string `json:"category"`
+	Meta     struct {
+		Duration float64 `json:"duration"`
+		Width    int     `json:"width"`
+		Height   int     `json:"height"`
+	} `json:"meta"`
+	LiveTranscodingTaskList         []*LiveTranscodingTask         `json:"live_transcoding_task_list"`
+	LiveTranscodingSubtitleTaskList []*LiveTranscodingSubtitleTask `json:"live_transcoding_subtitle_task_list"`
+}
+
+// BestTask 返回已完成转码中分辨率最高的一个
+func (i *VideoPreviewPlayInfo) BestTask() (*LiveTranscodingTask, bool) {
+	var best *LiveTranscodingTask
+	for _, task := range i.LiveTranscodingTaskList {
+		if task.Status != TranscodingStatusFinished || task.Url == "" {
+			continue
+		}
+		if best == nil || task.TemplateWidth*task.TemplateHeight > best.TemplateWidth*best.TemplateHeight {
+			best = task
+		}
+	}
+	return best, best != nil
+}
+
+// Task 返回指定模板的已完成转码
+func (i *VideoPreviewPlayInfo) Task(templateId string) (*LiveTranscodingTask, bool) {
+	for _, task := range i.LiveTranscodingTaskList {
+		if task.TemplateId == templateId && task.Status == TranscodingStatusFinished && task.Url != "" {
+			return task, true
+		}
+	}
+	return nil, false
+}
+
+type GetVideoPreviewPlayInfoRequest struct {
+	DriveId    string `json:"-"`
+	FileId     string `json:"file_id"`
+	TemplateId string `json:"template_id"`
+}
+
+type GetVideoPreviewPlayInfoResponse struct {
+	DriveId              string               `json:"drive_id"`
+	FileId               string               `json:"file_id"`
+	VideoPreviewPlayInfo VideoPreviewPlayInfo `json:"video_preview_play_info"`
+}
+
+func (c *Drive) DoGetVideoPreviewPlayInfoRequest(ctx context.Context, request GetVideoPreviewPlayInfoRequest) (*GetVideoPreviewPlayInfoResponse, error) {
+	driveId, err := c.resolveDriveId(ctx, request.DriveId)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &struct {
+		DriveId         string `json:"drive_id"`
+		Category        string `json:"category"`
+		GetSubtitleInfo bool   `json:"get_subtitle_info"`
+		GetVideoPreviewPlayInfoRequest
+	}{
+		DriveId:                        driveId,
+		Category:                       "live_transcoding",
+		GetSubtitleInfo:                true,
+		GetVideoPreviewPlayInfoRequest: request,
+	}
+
+	resp, err := c.requestWithCredit(ctx, "https://api.aliyundrive.com/v2/file/get_video_preview_play_info", params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(GetVideoPreviewPlayInfoResponse)
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,240 @@
+package webdav
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xbugio/aliyundrive-go-sdk"
+)
+
+// fakeDrive 是内存中的网盘，作为 http.RoundTripper 代替 api.aliyundrive.com 和下载、上传地址
+type fakeDrive struct {
+	lock   sync.Mutex
+	items  map[string]*aliyundrive.Item
+	data   map[string][]byte
+	nextId int
+	calls  map[string]int
+}
+
+func newFakeDrive() *fakeDrive {
+	d := &fakeDrive{
+		items: make(map[string]*aliyundrive.Item),
+		data:  make(map[string][]byte),
+		calls: make(map[string]int),
+	}
+	d.items[aliyundrive.RootFileId] = &aliyundrive.Item{FileId: aliyundrive.RootFileId, Name: "root", Type: "folder"}
+	return d
+}
+
+func (d *fakeDrive) drive() *aliyundrive.Drive {
+	return aliyundrive.New(
+		aliyundrive.WithDriveId("fake"),
+		aliyundrive.WithTokenManager(aliyundrive.NewStaticTokenManager("token")),
+		aliyundrive.WithHttpClient(&http.Client{Transport: d}),
+	)
+}
+
+func (d *fakeDrive) count(endpoint string) int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.calls[endpoint]
+}
+
+func (d *fakeDrive) addFolder(parentFileId string, name string) string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.add(parentFileId, name, "folder", nil).FileId
+}
+
+func (d *fakeDrive) addFile(parentFileId string, name string, content string) string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.add(parentFileId, name, "file", []byte(content)).FileId
+}
+
+func (d *fakeDrive) add(parentFileId string, name string, t string, data []byte) *aliyundrive.Item {
+	d.nextId++
+	now := time.Now()
+	item := &aliyundrive.Item{
+		FileId:       "f" + strconv.Itoa(d.nextId),
+		Name:         name,
+		ParentFileId: parentFileId,
+		Type:         t,
+		Size:         uint64(len(data)),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	d.items[item.FileId] = item
+	if t == "file" {
+		d.data[item.FileId] = data
+	}
+	return item
+}
+
+func (d *fakeDrive) children(parentFileId string) []*aliyundrive.Item {
+	var items []*aliyundrive.Item
+	for _, item := range d.items {
+		if item.ParentFileId == parentFileId && item.FileId != aliyundrive.RootFileId {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items
+}
+
+func (d *fakeDrive) child(parentFileId string, name string) *aliyundrive.Item {
+	for _, item := range d.children(parentFileId) {
+		if item.Name == name {
+			return item
+		}
+	}
+	return nil
+}
+
+func (d *fakeDrive) remove(fileId string) {
+	for _, child := range d.children(fileId) {
+		d.remove(child.FileId)
+	}
+	delete(d.items, fileId)
+	delete(d.data, fileId)
+}
+
+func (d *fakeDrive) copy(src *aliyundrive.Item, parentFileId string, name string) *aliyundrive.Item {
+	item := d.add(parentFileId, name, src.Type, d.data[src.FileId])
+	for _, child := range d.children(src.FileId) {
+		d.copy(child, item.FileId, child.Name)
+	}
+	return item
+}
+
+func (d *fakeDrive) RoundTrip(r *http.Request) (*http.Response, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	w := httptest.NewRecorder()
+	d.calls[r.URL.Path]++
+	if r.URL.Host == "oss.fake" {
+		d.serveOss(w, r)
+		return w.Result(), nil
+	}
+
+	body := make(map[string]any)
+	json.NewDecoder(r.Body).Decode(&body)
+	str := func(key string) string {
+		s, _ := body[key].(string)
+		return s
+	}
+	reply := func(v any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+	fail := func(status int, code string) {
+		w.WriteHeader(status)
+		reply(aliyundrive.ErrorResponse{Code: code, Message: code})
+	}
+
+	switch r.URL.Path {
+	case "/v2/file/get":
+		item, ok := d.items[str("file_id")]
+		if !ok {
+			fail(http.StatusNotFound, "NotFound.File")
+			break
+		}
+		reply(item)
+	case "/adrive/v3/file/list":
+		reply(aliyundrive.ListResponse{Items: d.children(str("parent_file_id"))})
+	case "/v2/file/get_download_url":
+		reply(aliyundrive.GetDownloadUrlResponse{Url: "https://oss.fake/download/" + str("file_id")})
+	case "/adrive/v2/file/createWithFolders":
+		parentFileId, name := str("parent_file_id"), str("name")
+		if str("type") == "folder" {
+			if d.child(parentFileId, name) != nil {
+				fail(http.StatusConflict, "AlreadyExist.File")
+				break
+			}
+			item := d.add(parentFileId, name, "folder", nil)
+			reply(aliyundrive.CreateFolderResponse{FileId: item.FileId, FileName: item.Name, ParentFileId: parentFileId, Type: "folder"})
+			break
+		}
+		// auto_rename
+		for i := 1; d.child(parentFileId, name) != nil; i++ {
+			name = fmt.Sprintf("%v(%d)", str("name"), i)
+		}
+		item := d.add(parentFileId, name, "file", nil)
+		item.Status = "uploading"
+		parts, _ := body["part_info_list"].([]any)
+		resp := aliyundrive.CreateFileResponse{FileId: item.FileId, FileName: name, ParentFileId: parentFileId, UploadId: "u" + item.FileId}
+		for i := range parts {
+			resp.PartInfoList = append(resp.PartInfoList, &aliyundrive.PartInfo{
+				PartNumber: i,
+				UploadUrl:  "https://oss.fake/upload/" + item.FileId,
+			})
+		}
+		reply(resp)
+	case "/v2/file/complete":
+		item := d.items[str("file_id")]
+		item.Status = "available"
+		item.Size = uint64(len(d.data[item.FileId]))
+		reply(item)
+	case "/v3/file/update":
+		item := d.items[str("file_id")]
+		if name := str("name"); name != "" {
+			// check_name_mode: refuse
+			if other := d.child(item.ParentFileId, name); other != nil && other != item {
+				fail(http.StatusConflict, "AlreadyExist.File")
+				break
+			}
+			item.Name = name
+		}
+		reply(item)
+	case "/v3/file/move":
+		item := d.items[str("file_id")]
+		item.ParentFileId = str("to_parent_file_id")
+		reply(aliyundrive.MoveResponse{FileId: item.FileId})
+	case "/v2/recyclebin/trash":
+		d.remove(str("file_id"))
+		reply(aliyundrive.TrashResponse{FileId: str("file_id")})
+	case "/v3/file/delete":
+		d.remove(str("file_id"))
+		w.WriteHeader(http.StatusNoContent)
+	case "/v2/file/copy":
+		src := d.items[str("file_id")]
+		name := str("new_name")
+		if name == "" {
+			name = src.Name
+		}
+		item := d.copy(src, str("to_parent_file_id"), name)
+		reply(aliyundrive.CopyResponse{FileId: item.FileId})
+	default:
+		fail(http.StatusNotFound, "NotFound.Endpoint")
+	}
+	return w.Result(), nil
+}
+
+func (d *fakeDrive) serveOss(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/download/"):
+		fileId := strings.TrimPrefix(r.URL.Path, "/download/")
+		data, ok := d.data[fileId]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeContent(w, r, fileId, time.Time{}, bytes.NewReader(data))
+	case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/upload/"):
+		fileId := strings.TrimPrefix(r.URL.Path, "/upload/")
+		data, _ := io.ReadAll(r.Body)
+		d.data[fileId] = append(d.data[fileId], data...)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
@@ -0,0 +1,179 @@
+package webdav
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrLocked = errors.New("locked")
+var ErrNoSuchLock = errors.New("no such lock")
+
+type lock struct {
+	token    string
+	root     string
+	infinite bool
+	owner    string
+	timeout  time.Duration
+	expires  time.Time
+}
+
+func (l *lock) covers(name string) bool {
+	if l.root == name {
+		return true
+	}
+	return l.infinite && isDescendant(l.root, name)
+}
+
+func (l *lock) expired(now time.Time) bool {
+	return now.After(l.expires)
+}
+
+// lockSystem 只支持排他写锁，锁信息保存在内存中
+type lockSystem struct {
+	mu    sync.Mutex
+	locks map[string]*lock
+}
+
+func newLockSystem() *lockSystem {
+	return &lockSystem{locks: make(map[string]*lock)}
+}
+
+func (s *lockSystem) create(name string, infinite bool, owner string, timeout time.Duration) (*lock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.collect(now)
+	for _, l := range s.locks {
+		if l.covers(name) || (infinite && isDescendant(name, l.root)) {
+			return nil, ErrLocked
+		}
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	l := &lock{
+		token:    token,
+		root:     name,
+		infinite: infinite,
+		owner:    owner,
+		timeout:  timeout,
+		expires:  now.Add(timeout),
+	}
+	s.locks[token] = l
+	return l, nil
+}
+
+// refresh 刷新 token 对应的锁，锁必须覆盖请求的路径 name
+func (s *lockSystem) refresh(name string, token string, timeout time.Duration) (*lock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.collect(now)
+	l, ok := s.locks[token]
+	if !ok || !l.covers(name) {
+		return nil, ErrNoSuchLock
+	}
+	l.timeout = timeout
+	l.expires = now.Add(timeout)
+	return l, nil
+}
+
+func (s *lockSystem) unlock(name string, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.collect(time.Now())
+	l, ok := s.locks[token]
+	if !ok || !l.covers(name) {
+		return ErrNoSuchLock
+	}
+	delete(s.locks, token)
+	return nil
+}
+
+// confirm 检查 name 上的所有锁是否都在 tokens 中提交了
+func (s *lockSystem) confirm(name string, tokens []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.collect(time.Now())
+	for _, l := range s.locks {
+		if !l.covers(name) && !isDescendant(name, l.root) {
+			continue
+		}
+		if !containsString(tokens, l.token) {
+			return ErrLocked
+		}
+	}
+	return nil
+}
+
+func (s *lockSystem) lookup(name string) []*lock {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.collect(time.Now())
+	var locks []*lock
+	for _, l := range s.locks {
+		if l.covers(name) {
+			locks = append(locks, l)
+		}
+	}
+	return locks
+}
+
+// removeAll 删除 name 及其子路径上的锁，用于 DELETE 和 MOVE 之后
+func (s *lockSystem) removeAll(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for token, l := range s.locks {
+		if l.root == name || isDescendant(name, l.root) {
+			delete(s.locks, token)
+		}
+	}
+}
+
+func (s *lockSystem) collect(now time.Time) {
+	for token, l := range s.locks {
+		if l.expired(now) {
+			delete(s.locks, token)
+		}
+	}
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("opaquelocktoken:%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// isDescendant 判断 name 是否在 dir 之下（不包括 dir 本身）
+func isDescendant(dir, name string) bool {
+	if dir == "/" {
+		return name != "/"
+	}
+	return strings.HasPrefix(name, dir+"/")
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
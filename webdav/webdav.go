@@ -0,0 +1,775 @@
+package webdav
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xbugio/aliyundrive-go-sdk"
+	aliyunfs "github.com/xbugio/aliyundrive-go-sdk/fs"
+)
+
+const defaultLockTimeout = time.Hour
+
+// maxLockTimeout 是锁的最长有效期，Infinite 也按此处理，避免客户端异常退出后路径一直被锁定
+const maxLockTimeout = 24 * time.Hour
+
+var errUnsupported = errors.New("operation not supported")
+
+type Handler struct {
+	fs     fs.FS
+	prefix string
+	locks  *lockSystem
+}
+
+// NewHandler 创建 WebDAV Handler，prefix 为挂载的 URL 路径前缀。
+// 写操作需要 fsys 实现 fs 包中对应的可选接口，否则返回 405。
+// WebDAV 客户端会频繁按路径访问，建议使用 fs.WithCache(fs.DefaultCacheTTL) 创建文件系统，
+// 通过 Handler 的写操作会使相关路径的缓存失效。
+func NewHandler(fsys fs.FS, prefix string) *Handler {
+	return &Handler{
+		fs:     fsys,
+		prefix: strings.TrimSuffix(prefix, "/"),
+		locks:  newLockSystem(),
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, ok := h.stripPrefix(r.URL.Path)
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	// 绑定请求的 context，客户端断开时取消对网盘的请求
+	if fsys, ok := h.fs.(aliyunfs.ContextFS); ok {
+		view := *h
+		view.fs = fsys.WithContext(r.Context())
+		h = &view
+	}
+
+	var status int
+	switch r.Method {
+	case "OPTIONS":
+		status = h.handleOptions(w, r, name)
+	case "GET", "HEAD":
+		status = h.handleGet(w, r, name)
+	case "PUT":
+		status = h.handlePut(w, r, name)
+	case "DELETE":
+		status = h.handleDelete(w, r, name)
+	case "MKCOL":
+		status = h.handleMkcol(w, r, name)
+	case "COPY", "MOVE":
+		status = h.handleCopyMove(w, r, name)
+	case "PROPFIND":
+		status = h.handlePropfind(w, r, name)
+	case "PROPPATCH":
+		status = h.handleProppatch(w, r, name)
+	case "LOCK":
+		status = h.handleLock(w, r, name)
+	case "UNLOCK":
+		status = h.handleUnlock(w, r, name)
+	default:
+		status = http.StatusMethodNotAllowed
+	}
+
+	if status != 0 {
+		w.WriteHeader(status)
+		if status != http.StatusNoContent && status != http.StatusCreated {
+			io.WriteString(w, http.StatusText(status))
+		}
+	}
+}
+
+func (h *Handler) handleOptions(w http.ResponseWriter, r *http.Request, name string) int {
+	allow := "OPTIONS, LOCK, PUT, MKCOL"
+	if info, err := fs.Stat(h.fs, fsName(name)); err == nil {
+		if info.IsDir() {
+			allow = "OPTIONS, LOCK, DELETE, PROPPATCH, COPY, MOVE, UNLOCK, PROPFIND"
+		} else {
+			allow = "OPTIONS, LOCK, GET, HEAD, POST, DELETE, PROPPATCH, COPY, MOVE, UNLOCK, PROPFIND, PUT"
+		}
+	}
+	w.Header().Set("Allow", allow)
+	w.Header().Set("DAV", "1, 2")
+	w.Header().Set("MS-Author-Via", "DAV")
+	return http.StatusOK
+}
+
+func (h *Handler) handleGet(w http.ResponseWriter, r *http.Request, name string) int {
+	file, err := h.fs.Open(fsName(name))
+	if err != nil {
+		return errorStatus(err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return errorStatus(err)
+	}
+	if info.IsDir() {
+		return http.StatusMethodNotAllowed
+	}
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		return http.StatusNotImplemented
+	}
+
+	w.Header().Set("ETag", etag(info))
+	w.Header().Set("Content-Type", contentType(info))
+	http.ServeContent(w, r, info.Name(), info.ModTime(), content)
+	return 0
+}
+
+func (h *Handler) handlePut(w http.ResponseWriter, r *http.Request, name string) int {
+	fsys, ok := h.fs.(aliyunfs.OpenFileFS)
+	if !ok {
+		return http.StatusMethodNotAllowed
+	}
+	if err := h.locks.confirm(name, ifTokens(r)); err != nil {
+		return http.StatusLocked
+	}
+	if status := h.checkParent(name); status != 0 {
+		return status
+	}
+
+	status := http.StatusCreated
+	if info, err := fs.Stat(h.fs, fsName(name)); err == nil {
+		if info.IsDir() {
+			return http.StatusMethodNotAllowed
+		}
+		status = http.StatusNoContent
+	}
+
+	err := writeFile(fsys, fsName(name), r.Body)
+	if err != nil {
+		return errorStatus(err)
+	}
+	return status
+}
+
+func (h *Handler) handleDelete(w http.ResponseWriter, r *http.Request, name string) int {
+	fsys, ok := h.fs.(aliyunfs.RemoveAllFS)
+	if !ok {
+		return http.StatusMethodNotAllowed
+	}
+	if err := h.locks.confirm(name, ifTokens(r)); err != nil {
+		return http.StatusLocked
+	}
+	if _, err := fs.Stat(h.fs, fsName(name)); err != nil {
+		return errorStatus(err)
+	}
+	if err := fsys.RemoveAll(fsName(name)); err != nil {
+		return errorStatus(err)
+	}
+	h.locks.removeAll(name)
+	return http.StatusNoContent
+}
+
+func (h *Handler) handleMkcol(w http.ResponseWriter, r *http.Request, name string) int {
+	fsys, ok := h.fs.(aliyunfs.MkdirFS)
+	if !ok {
+		return http.StatusMethodNotAllowed
+	}
+	if r.ContentLength > 0 {
+		return http.StatusUnsupportedMediaType
+	}
+	if err := h.locks.confirm(name, ifTokens(r)); err != nil {
+		return http.StatusLocked
+	}
+	if status := h.checkParent(name); status != 0 {
+		return status
+	}
+	if _, err := fs.Stat(h.fs, fsName(name)); err == nil {
+		return http.StatusMethodNotAllowed
+	}
+	if err := fsys.Mkdir(fsName(name), 0); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return http.StatusMethodNotAllowed
+		}
+		return errorStatus(err)
+	}
+	return http.StatusCreated
+}
+
+func (h *Handler) handleCopyMove(w http.ResponseWriter, r *http.Request, src string) int {
+	dst, status := h.destination(r)
+	if status != 0 {
+		return status
+	}
+	if dst == src {
+		return http.StatusForbidden
+	}
+	if isDescendant(src, dst) {
+		return http.StatusBadRequest
+	}
+
+	tokens := ifTokens(r)
+	if r.Method == "MOVE" {
+		if err := h.locks.confirm(src, tokens); err != nil {
+			return http.StatusLocked
+		}
+	}
+	if err := h.locks.confirm(dst, tokens); err != nil {
+		return http.StatusLocked
+	}
+
+	srcInfo, err := fs.Stat(h.fs, fsName(src))
+	if err != nil {
+		return errorStatus(err)
+	}
+	if status := h.checkParent(dst); status != 0 {
+		return status
+	}
+
+	recursive := true
+	if r.Method == "COPY" {
+		switch r.Header.Get("Depth") {
+		case "", "infinity":
+		case "0":
+			recursive = false
+		default:
+			return http.StatusBadRequest
+		}
+	}
+
+	status = http.StatusCreated
+	var aside string
+	if _, err := fs.Stat(h.fs, fsName(dst)); err == nil {
+		if r.Header.Get("Overwrite") == "F" {
+			return http.StatusPreconditionFailed
+		}
+		// 已有的目标先改名保留，操作成功后再删除，失败时恢复
+		aside, err = h.moveAside(dst)
+		if err != nil {
+			return errorStatus(err)
+		}
+		status = http.StatusNoContent
+	}
+
+	if r.Method == "MOVE" {
+		err = h.rename(src, dst)
+	} else {
+		err = h.copy(src, dst, srcInfo, recursive)
+		if err != nil && aside != "" {
+			// 删除复制了一部分的目标，才能恢复原来的目标
+			h.removeAll(dst)
+		}
+	}
+	if err != nil {
+		if aside != "" {
+			h.rename(aside, dst)
+		}
+		return errorStatus(err)
+	}
+	if r.Method == "MOVE" {
+		h.locks.removeAll(src)
+	}
+	if aside != "" {
+		h.locks.removeAll(dst)
+		if err := h.removeAll(aside); err != nil {
+			return errorStatus(err)
+		}
+	}
+	return status
+}
+
+// moveAside 把 name 改为同一目录下的临时名称，返回新的路径
+func (h *Handler) moveAside(name string) (string, error) {
+	if _, ok := h.fs.(aliyunfs.RemoveAllFS); !ok {
+		return "", errUnsupported
+	}
+	aside := path.Join(path.Dir(name), ".~"+strconv.FormatInt(time.Now().UnixNano(), 36)+"-"+path.Base(name))
+	err := h.rename(name, aside)
+	if err != nil {
+		return "", err
+	}
+	return aside, nil
+}
+
+func (h *Handler) rename(oldname, newname string) error {
+	fsys, ok := h.fs.(aliyunfs.RenameFS)
+	if !ok {
+		return errUnsupported
+	}
+	return fsys.Rename(fsName(oldname), fsName(newname))
+}
+
+func (h *Handler) removeAll(name string) error {
+	fsys, ok := h.fs.(aliyunfs.RemoveAllFS)
+	if !ok {
+		return errUnsupported
+	}
+	return fsys.RemoveAll(fsName(name))
+}
+
+func (h *Handler) handlePropfind(w http.ResponseWriter, r *http.Request, name string) int {
+	depth := r.Header.Get("Depth")
+	switch depth {
+	case "0", "1":
+	case "":
+		// RFC 4918 中缺省为 infinity，常见客户端不带 Depth，按 1 处理
+		depth = "1"
+	case "infinity":
+		// 不支持 infinity，避免遍历整个网盘
+		writeError(w, http.StatusForbidden, "propfind-finite-depth")
+		return 0
+	default:
+		return http.StatusBadRequest
+	}
+
+	pf := new(propfind)
+	err := xml.NewDecoder(r.Body).Decode(pf)
+	if err != nil && err != io.EOF {
+		return http.StatusBadRequest
+	}
+
+	info, err := fs.Stat(h.fs, fsName(name))
+	if err != nil {
+		return errorStatus(err)
+	}
+
+	responses := []response{h.propfindResponse(name, info, pf)}
+	if depth == "1" && info.IsDir() {
+		entries, err := fs.ReadDir(h.fs, fsName(name))
+		if err != nil {
+			return errorStatus(err)
+		}
+		for _, entry := range entries {
+			childInfo, err := entry.Info()
+			if err != nil {
+				return errorStatus(err)
+			}
+			responses = append(responses, h.propfindResponse(path.Join(name, entry.Name()), childInfo, pf))
+		}
+	}
+
+	writeMultistatus(w, responses)
+	return 0
+}
+
+// handleProppatch 不支持存储自定义属性，所有属性都返回 403
+func (h *Handler) handleProppatch(w http.ResponseWriter, r *http.Request, name string) int {
+	if err := h.locks.confirm(name, ifTokens(r)); err != nil {
+		return http.StatusLocked
+	}
+	if _, err := fs.Stat(h.fs, fsName(name)); err != nil {
+		return errorStatus(err)
+	}
+
+	pu := new(propertyupdate)
+	if err := xml.NewDecoder(r.Body).Decode(pu); err != nil {
+		return http.StatusBadRequest
+	}
+
+	var props []property
+	for _, set := range pu.Set {
+		for _, n := range set.Prop.Names {
+			props = append(props, property{XMLName: n.XMLName})
+		}
+	}
+	for _, remove := range pu.Remove {
+		for _, n := range remove.Prop.Names {
+			props = append(props, property{XMLName: n.XMLName})
+		}
+	}
+
+	writeMultistatus(w, []response{{
+		Href:     h.href(name, false),
+		Propstat: []propstat{{Props: props, Status: statusText(http.StatusForbidden)}},
+	}})
+	return 0
+}
+
+func (h *Handler) handleLock(w http.ResponseWriter, r *http.Request, name string) int {
+	timeout, err := parseTimeout(r.Header.Get("Timeout"))
+	if err != nil {
+		return http.StatusBadRequest
+	}
+
+	li := new(lockinfo)
+	err = xml.NewDecoder(r.Body).Decode(li)
+	if err == io.EOF {
+		// 空请求体表示刷新锁
+		tokens := ifTokens(r)
+		if len(tokens) == 0 {
+			return http.StatusBadRequest
+		}
+		l, err := h.locks.refresh(name, tokens[0], timeout)
+		if err != nil {
+			return http.StatusPreconditionFailed
+		}
+		h.writeLock(w, l, http.StatusOK)
+		return 0
+	}
+	if err != nil || li.Exclusive == nil || li.Write == nil {
+		return http.StatusBadRequest
+	}
+
+	infinite := true
+	switch r.Header.Get("Depth") {
+	case "", "infinity":
+	case "0":
+		infinite = false
+	default:
+		return http.StatusBadRequest
+	}
+
+	l, err := h.locks.create(name, infinite, li.Owner.InnerXML, timeout)
+	if err != nil {
+		return http.StatusLocked
+	}
+
+	status := http.StatusOK
+	if _, err := fs.Stat(h.fs, fsName(name)); errors.Is(err, fs.ErrNotExist) {
+		// 对不存在的资源加锁时创建空文件
+		status, err = h.createEmpty(name)
+		if err != nil {
+			h.locks.unlock(name, l.token)
+			return status
+		}
+	}
+
+	w.Header().Set("Lock-Token", "<"+l.token+">")
+	h.writeLock(w, l, status)
+	return 0
+}
+
+func (h *Handler) handleUnlock(w http.ResponseWriter, r *http.Request, name string) int {
+	token := strings.TrimSpace(r.Header.Get("Lock-Token"))
+	if len(token) < 2 || token[0] != '<' || token[len(token)-1] != '>' {
+		return http.StatusBadRequest
+	}
+	if err := h.locks.unlock(name, token[1:len(token)-1]); err != nil {
+		return http.StatusConflict
+	}
+	return http.StatusNoContent
+}
+
+func (h *Handler) createEmpty(name string) (int, error) {
+	fsys, ok := h.fs.(aliyunfs.OpenFileFS)
+	if !ok {
+		return http.StatusMethodNotAllowed, errUnsupported
+	}
+	if status := h.checkParent(name); status != 0 {
+		return status, fs.ErrNotExist
+	}
+	err := writeFile(fsys, fsName(name), strings.NewReader(""))
+	if err != nil {
+		return errorStatus(err), err
+	}
+	return http.StatusCreated, nil
+}
+
+func (h *Handler) writeLock(w http.ResponseWriter, l *lock, status int) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(status)
+	io.WriteString(w, xml.Header)
+	io.WriteString(w, `<D:prop xmlns:D="DAV:"><D:lockdiscovery>`)
+	io.WriteString(w, activeLockXML(l, h.href(l.root, false)))
+	io.WriteString(w, `</D:lockdiscovery></D:prop>`)
+}
+
+func (h *Handler) copy(src, dst string, info fs.FileInfo, recursive bool) error {
+	if fsys, ok := h.fs.(aliyunfs.CopyFS); ok && (recursive || !info.IsDir()) {
+		return fsys.Copy(fsName(src), fsName(dst))
+	}
+	if !info.IsDir() {
+		return h.copyFile(src, dst)
+	}
+
+	fsys, ok := h.fs.(aliyunfs.MkdirFS)
+	if !ok {
+		return errUnsupported
+	}
+	if err := fsys.Mkdir(fsName(dst), 0); err != nil {
+		return err
+	}
+	if !recursive {
+		return nil
+	}
+
+	entries, err := fs.ReadDir(h.fs, fsName(src))
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		childInfo, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		err = h.copy(path.Join(src, entry.Name()), path.Join(dst, entry.Name()), childInfo, true)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handler) copyFile(src, dst string) error {
+	fsys, ok := h.fs.(aliyunfs.OpenFileFS)
+	if !ok {
+		return errUnsupported
+	}
+
+	in, err := h.fs.Open(fsName(src))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	return writeFile(fsys, fsName(dst), in)
+}
+
+func (h *Handler) propfindResponse(name string, info fs.FileInfo, pf *propfind) response {
+	props := h.properties(name, info)
+
+	if pf.PropName != nil {
+		var names []property
+		for _, p := range props {
+			names = append(names, property{XMLName: p.XMLName})
+		}
+		return response{
+			Href:     h.href(name, info.IsDir()),
+			Propstat: []propstat{{Props: names, Status: statusText(http.StatusOK)}},
+		}
+	}
+
+	if pf.Prop == nil {
+		return response{
+			Href:     h.href(name, info.IsDir()),
+			Propstat: []propstat{{Props: props, Status: statusText(http.StatusOK)}},
+		}
+	}
+
+	var found, missing []property
+	for _, n := range pf.Prop.Names {
+		p, ok := findProperty(props, n.XMLName)
+		if ok {
+			found = append(found, p)
+		} else {
+			missing = append(missing, property{XMLName: n.XMLName})
+		}
+	}
+
+	resp := response{Href: h.href(name, info.IsDir())}
+	if len(found) > 0 {
+		resp.Propstat = append(resp.Propstat, propstat{Props: found, Status: statusText(http.StatusOK)})
+	}
+	if len(missing) > 0 {
+		resp.Propstat = append(resp.Propstat, propstat{Props: missing, Status: statusText(http.StatusNotFound)})
+	}
+	return resp
+}
+
+func (h *Handler) properties(name string, info fs.FileInfo) []property {
+	displayName := info.Name()
+	if name == "/" {
+		displayName = ""
+	}
+	props := []property{
+		{XMLName: davName("displayname"), InnerXML: escapeXML(displayName)},
+		{XMLName: davName("getlastmodified"), InnerXML: info.ModTime().UTC().Format(http.TimeFormat)},
+		{XMLName: davName("creationdate"), InnerXML: creationDate(info).UTC().Format(time.RFC3339)},
+		{XMLName: davName("supportedlock"), InnerXML: supportedLockXML},
+	}
+	if info.IsDir() {
+		props = append(props, property{XMLName: davName("resourcetype"), InnerXML: `<D:collection xmlns:D="DAV:"/>`})
+	} else {
+		props = append(props,
+			property{XMLName: davName("resourcetype")},
+			property{XMLName: davName("getcontentlength"), InnerXML: strconv.FormatInt(info.Size(), 10)},
+			property{XMLName: davName("getcontenttype"), InnerXML: escapeXML(contentType(info))},
+			property{XMLName: davName("getetag"), InnerXML: escapeXML(etag(info))},
+		)
+	}
+
+	var lockdiscovery strings.Builder
+	for _, l := range h.locks.lookup(name) {
+		lockdiscovery.WriteString(activeLockXML(l, h.href(l.root, false)))
+	}
+	props = append(props, property{XMLName: davName("lockdiscovery"), InnerXML: lockdiscovery.String()})
+	return props
+}
+
+// checkParent 检查 name 的父目录是否存在，不存在时返回 409
+func (h *Handler) checkParent(name string) int {
+	info, err := fs.Stat(h.fs, fsName(path.Dir(name)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return http.StatusConflict
+	}
+	if err != nil {
+		return errorStatus(err)
+	}
+	if !info.IsDir() {
+		return http.StatusConflict
+	}
+	return 0
+}
+
+func (h *Handler) destination(r *http.Request) (string, int) {
+	u, err := url.Parse(r.Header.Get("Destination"))
+	if err != nil || u.Path == "" {
+		return "", http.StatusBadRequest
+	}
+	if u.Host != "" && u.Host != r.Host {
+		return "", http.StatusBadGateway
+	}
+	name, ok := h.stripPrefix(u.Path)
+	if !ok {
+		return "", http.StatusBadGateway
+	}
+	return name, 0
+}
+
+func (h *Handler) stripPrefix(p string) (string, bool) {
+	if h.prefix == "" {
+		return path.Clean("/" + p), true
+	}
+	if p != h.prefix && !strings.HasPrefix(p, h.prefix+"/") {
+		return "", false
+	}
+	return path.Clean("/" + strings.TrimPrefix(p, h.prefix)), true
+}
+
+func (h *Handler) href(name string, dir bool) string {
+	p := h.prefix + name
+	if dir && !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	return (&url.URL{Path: p}).EscapedPath()
+}
+
+func writeFile(fsys aliyunfs.OpenFileFS, name string, r io.Reader) error {
+	file, err := fsys.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0)
+	if err != nil {
+		return err
+	}
+	w, ok := file.(io.Writer)
+	if !ok {
+		file.Close()
+		return errUnsupported
+	}
+	_, err = io.Copy(w, r)
+	closeErr := file.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+func findProperty(props []property, name xml.Name) (property, bool) {
+	for _, p := range props {
+		if p.XMLName == name {
+			return p, true
+		}
+	}
+	return property{}, false
+}
+
+// fsName 把 WebDAV 路径转换为 io/fs 要求的相对路径
+func fsName(name string) string {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if name == "" {
+		return "."
+	}
+	return name
+}
+
+// ifTokens 从 If 请求头中提取所有的锁 token
+func ifTokens(r *http.Request) []string {
+	var tokens []string
+	header := r.Header.Get("If")
+	for {
+		start := strings.Index(header, "<")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(header[start:], ">")
+		if end < 0 {
+			break
+		}
+		token := header[start+1 : start+end]
+		if strings.HasPrefix(token, "opaquelocktoken:") {
+			tokens = append(tokens, token)
+		}
+		header = header[start+end+1:]
+	}
+	return tokens
+}
+
+func parseTimeout(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, ","); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	if s == "" {
+		return defaultLockTimeout, nil
+	}
+	if s == "Infinite" {
+		return maxLockTimeout, nil
+	}
+	if !strings.HasPrefix(s, "Second-") {
+		return 0, errors.New("invalid timeout")
+	}
+	n, err := strconv.ParseInt(s[len("Second-"):], 10, 64)
+	if err != nil || n <= 0 {
+		return 0, errors.New("invalid timeout")
+	}
+	if n > int64(maxLockTimeout/time.Second) {
+		return maxLockTimeout, nil
+	}
+	return time.Duration(n) * time.Second, nil
+}
+
+func creationDate(info fs.FileInfo) time.Time {
+	if item, ok := info.Sys().(*aliyundrive.Item); ok && !item.CreatedAt.IsZero() {
+		return item.CreatedAt
+	}
+	return info.ModTime()
+}
+
+func contentType(info fs.FileInfo) string {
+	if m, ok := info.(aliyunfs.MimeTyper); ok && m.MimeType() != "" {
+		return m.MimeType()
+	}
+	ct := mime.TypeByExtension(path.Ext(info.Name()))
+	if ct == "" {
+		ct = "application/octet-stream"
+	}
+	return ct
+}
+
+func etag(info fs.FileInfo) string {
+	if h, ok := info.(aliyunfs.ContentHasher); ok && h.ContentHash() != "" {
+		return `"` + h.ContentHash() + `"`
+	}
+	return `"` + strconv.FormatInt(info.ModTime().UnixNano(), 16) + strconv.FormatInt(info.Size(), 16) + `"`
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return http.StatusNotFound
+	case errors.Is(err, fs.ErrExist):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, fs.ErrPermission):
+		return http.StatusForbidden
+	case errors.Is(err, fs.ErrInvalid):
+		return http.StatusBadRequest
+	case errors.Is(err, aliyunfs.ErrNotEmpty):
+		return http.StatusConflict
+	case errors.Is(err, errUnsupported):
+		return http.StatusMethodNotAllowed
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,368 @@
+package webdav
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/xbugio/aliyundrive-go-sdk"
+	aliyunfs "github.com/xbugio/aliyundrive-go-sdk/fs"
+)
+
+// newTestServer 创建包含 /a.txt 和 /dir/b.txt 的网盘，WebDAV 挂载在 /dav
+func newTestServer(t *testing.T) (*fakeDrive, *httptest.Server) {
+	d := newFakeDrive()
+	d.addFile(aliyundrive.RootFileId, "a.txt", "aaa")
+	dir := d.addFolder(aliyundrive.RootFileId, "dir")
+	d.addFile(dir, "b.txt", "bbb")
+
+	fsys := aliyunfs.New(d.drive(), "/", aliyunfs.WithCache(aliyunfs.DefaultCacheTTL))
+	server := httptest.NewServer(NewHandler(fsys, "/dav"))
+	t.Cleanup(server.Close)
+	return d, server
+}
+
+func do(t *testing.T, server *httptest.Server, method string, name string, body string, header map[string]string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, server.URL+name, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func expectStatus(t *testing.T, resp *http.Response, status int) {
+	t.Helper()
+	if resp.StatusCode != status {
+		t.Fatalf("%v %v: status %v, want %v", resp.Request.Method, resp.Request.URL.Path, resp.StatusCode, status)
+	}
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func expectBody(t *testing.T, server *httptest.Server, name string, want string) {
+	t.Helper()
+	resp := do(t, server, "GET", name, "", nil)
+	expectStatus(t, resp, http.StatusOK)
+	if body := readBody(t, resp); body != want {
+		t.Fatalf("GET %v: got %q, want %q", name, body, want)
+	}
+}
+
+// expectChildren 检查目录中的文件数量
+func expectChildren(t *testing.T, server *httptest.Server, name string, want int) {
+	t.Helper()
+	resp := do(t, server, "PROPFIND", name, "", map[string]string{"Depth": "1"})
+	expectStatus(t, resp, http.StatusMultiStatus)
+	body := readBody(t, resp)
+	if n := strings.Count(body, "<D:response>") - 1; n != want {
+		t.Fatalf("%v has %v children, want %v:\n%v", name, n, want, body)
+	}
+}
+
+func TestPropfindDepth0(t *testing.T) {
+	_, server := newTestServer(t)
+
+	resp := do(t, server, "PROPFIND", "/dav/", "", map[string]string{"Depth": "0"})
+	expectStatus(t, resp, http.StatusMultiStatus)
+	body := readBody(t, resp)
+	if n := strings.Count(body, "<D:response>"); n != 1 {
+		t.Fatalf("got %v responses, want 1:\n%v", n, body)
+	}
+	if !strings.Contains(body, "<D:collection") {
+		t.Fatalf("root is not a collection:\n%v", body)
+	}
+}
+
+func TestPropfindDepth1(t *testing.T) {
+	_, server := newTestServer(t)
+
+	resp := do(t, server, "PROPFIND", "/dav/", "", map[string]string{"Depth": "1"})
+	expectStatus(t, resp, http.StatusMultiStatus)
+	body := readBody(t, resp)
+	if n := strings.Count(body, "<D:response>"); n != 3 {
+		t.Fatalf("got %v responses, want 3:\n%v", n, body)
+	}
+	for _, href := range []string{"/dav/a.txt", "/dav/dir/"} {
+		if !strings.Contains(body, "<D:href>"+href+"</D:href>") {
+			t.Fatalf("missing %v:\n%v", href, body)
+		}
+	}
+
+	resp = do(t, server, "PROPFIND", "/dav/missing", "", map[string]string{"Depth": "1"})
+	expectStatus(t, resp, http.StatusNotFound)
+}
+
+func TestPropfindDepthHeader(t *testing.T) {
+	_, server := newTestServer(t)
+
+	// 没有 Depth 时按 1 处理
+	resp := do(t, server, "PROPFIND", "/dav/", "", nil)
+	expectStatus(t, resp, http.StatusMultiStatus)
+	body := readBody(t, resp)
+	if n := strings.Count(body, "<D:response>"); n != 3 {
+		t.Fatalf("got %v responses, want 3:\n%v", n, body)
+	}
+
+	resp = do(t, server, "PROPFIND", "/dav/", "", map[string]string{"Depth": "infinity"})
+	expectStatus(t, resp, http.StatusForbidden)
+	if body := readBody(t, resp); !strings.Contains(body, "<D:propfind-finite-depth/>") {
+		t.Fatalf("missing propfind-finite-depth:\n%v", body)
+	}
+}
+
+func TestGetRange(t *testing.T) {
+	_, server := newTestServer(t)
+
+	resp := do(t, server, "GET", "/dav/dir/b.txt", "", map[string]string{"Range": "bytes=1-"})
+	expectStatus(t, resp, http.StatusPartialContent)
+	if body := readBody(t, resp); body != "bb" {
+		t.Fatalf("got %q, want %q", body, "bb")
+	}
+	if cr := resp.Header.Get("Content-Range"); cr != "bytes 1-2/3" {
+		t.Fatalf("got Content-Range %q, want %q", cr, "bytes 1-2/3")
+	}
+}
+
+func TestPut(t *testing.T) {
+	_, server := newTestServer(t)
+
+	expectStatus(t, do(t, server, "PUT", "/dav/dir/new.txt", "hello", nil), http.StatusCreated)
+	resp := do(t, server, "GET", "/dav/dir/new.txt", "", nil)
+	expectStatus(t, resp, http.StatusOK)
+	if body := readBody(t, resp); body != "hello" {
+		t.Fatalf("got %q, want %q", body, "hello")
+	}
+
+	// 覆盖已有的文件
+	expectStatus(t, do(t, server, "PUT", "/dav/dir/new.txt", "world", nil), http.StatusNoContent)
+	resp = do(t, server, "GET", "/dav/dir/new.txt", "", nil)
+	expectStatus(t, resp, http.StatusOK)
+	if body := readBody(t, resp); body != "world" {
+		t.Fatalf("got %q, want %q", body, "world")
+	}
+
+	expectStatus(t, do(t, server, "PUT", "/dav/missing/new.txt", "hello", nil), http.StatusConflict)
+}
+
+func TestMkcol(t *testing.T) {
+	_, server := newTestServer(t)
+
+	expectStatus(t, do(t, server, "MKCOL", "/dav/newdir", "", nil), http.StatusCreated)
+	expectStatus(t, do(t, server, "MKCOL", "/dav/newdir", "", nil), http.StatusMethodNotAllowed)
+	expectStatus(t, do(t, server, "MKCOL", "/dav/missing/newdir", "", nil), http.StatusConflict)
+	expectStatus(t, do(t, server, "PROPFIND", "/dav/newdir", "", map[string]string{"Depth": "0"}), http.StatusMultiStatus)
+}
+
+func TestMove(t *testing.T) {
+	_, server := newTestServer(t)
+
+	// 先读取一次，确认缓存中的旧路径会失效
+	expectStatus(t, do(t, server, "GET", "/dav/a.txt", "", nil), http.StatusOK)
+
+	resp := do(t, server, "MOVE", "/dav/a.txt", "", map[string]string{"Destination": server.URL + "/dav/dir/c.txt"})
+	expectStatus(t, resp, http.StatusCreated)
+	expectStatus(t, do(t, server, "GET", "/dav/a.txt", "", nil), http.StatusNotFound)
+	resp = do(t, server, "GET", "/dav/dir/c.txt", "", nil)
+	expectStatus(t, resp, http.StatusOK)
+	if body := readBody(t, resp); body != "aaa" {
+		t.Fatalf("got %q, want %q", body, "aaa")
+	}
+
+	// 目标已存在且 Overwrite: F
+	resp = do(t, server, "MOVE", "/dav/dir/c.txt", "", map[string]string{
+		"Destination": server.URL + "/dav/dir/b.txt",
+		"Overwrite":   "F",
+	})
+	expectStatus(t, resp, http.StatusPreconditionFailed)
+}
+
+func TestMoveOverwrite(t *testing.T) {
+	_, server := newTestServer(t)
+
+	resp := do(t, server, "MOVE", "/dav/a.txt", "", map[string]string{
+		"Destination": server.URL + "/dav/dir/b.txt",
+		"Overwrite":   "T",
+	})
+	expectStatus(t, resp, http.StatusNoContent)
+	expectStatus(t, do(t, server, "GET", "/dav/a.txt", "", nil), http.StatusNotFound)
+	expectBody(t, server, "/dav/dir/b.txt", "aaa")
+	expectChildren(t, server, "/dav/dir/", 1)
+}
+
+func TestCopyFile(t *testing.T) {
+	_, server := newTestServer(t)
+
+	resp := do(t, server, "COPY", "/dav/a.txt", "", map[string]string{"Destination": server.URL + "/dav/dir/c.txt"})
+	expectStatus(t, resp, http.StatusCreated)
+	expectBody(t, server, "/dav/a.txt", "aaa")
+	expectBody(t, server, "/dav/dir/c.txt", "aaa")
+
+	resp = do(t, server, "COPY", "/dav/a.txt", "", map[string]string{
+		"Destination": server.URL + "/dav/dir/b.txt",
+		"Overwrite":   "F",
+	})
+	expectStatus(t, resp, http.StatusPreconditionFailed)
+	expectBody(t, server, "/dav/dir/b.txt", "bbb")
+
+	resp = do(t, server, "COPY", "/dav/a.txt", "", map[string]string{
+		"Destination": server.URL + "/dav/dir/b.txt",
+		"Overwrite":   "T",
+	})
+	expectStatus(t, resp, http.StatusNoContent)
+	expectBody(t, server, "/dav/dir/b.txt", "aaa")
+	// 被替换的文件在复制完成后删除，不会留下临时文件
+	expectChildren(t, server, "/dav/dir/", 2)
+}
+
+func TestCopyFolder(t *testing.T) {
+	_, server := newTestServer(t)
+
+	resp := do(t, server, "COPY", "/dav/dir", "", map[string]string{"Destination": server.URL + "/dav/dir2"})
+	expectStatus(t, resp, http.StatusCreated)
+	expectBody(t, server, "/dav/dir2/b.txt", "bbb")
+
+	expectStatus(t, do(t, server, "MKCOL", "/dav/other", "", nil), http.StatusCreated)
+	expectStatus(t, do(t, server, "PUT", "/dav/other/x.txt", "x", nil), http.StatusCreated)
+
+	resp = do(t, server, "COPY", "/dav/dir", "", map[string]string{
+		"Destination": server.URL + "/dav/other",
+		"Overwrite":   "F",
+	})
+	expectStatus(t, resp, http.StatusPreconditionFailed)
+	expectBody(t, server, "/dav/other/x.txt", "x")
+
+	resp = do(t, server, "COPY", "/dav/dir", "", map[string]string{
+		"Destination": server.URL + "/dav/other",
+		"Overwrite":   "T",
+	})
+	expectStatus(t, resp, http.StatusNoContent)
+	expectStatus(t, do(t, server, "GET", "/dav/other/x.txt", "", nil), http.StatusNotFound)
+	expectBody(t, server, "/dav/other/b.txt", "bbb")
+	// a.txt、dir、dir2、other
+	expectChildren(t, server, "/dav/", 4)
+}
+
+func TestProppatch(t *testing.T) {
+	_, server := newTestServer(t)
+
+	body := `<?xml version="1.0" encoding="utf-8"?>
+<D:propertyupdate xmlns:D="DAV:" xmlns:Z="urn:example"><D:set><D:prop><Z:color>red</Z:color></D:prop></D:set></D:propertyupdate>`
+	resp := do(t, server, "PROPPATCH", "/dav/a.txt", body, nil)
+	expectStatus(t, resp, http.StatusMultiStatus)
+	respBody := readBody(t, resp)
+	if !strings.Contains(respBody, "color") || !strings.Contains(respBody, "403") {
+		t.Fatalf("property not rejected:\n%v", respBody)
+	}
+
+	expectStatus(t, do(t, server, "PROPPATCH", "/dav/missing", body, nil), http.StatusNotFound)
+}
+
+func TestDelete(t *testing.T) {
+	_, server := newTestServer(t)
+
+	expectStatus(t, do(t, server, "PROPFIND", "/dav/dir/", "", map[string]string{"Depth": "1"}), http.StatusMultiStatus)
+	expectStatus(t, do(t, server, "DELETE", "/dav/dir", "", nil), http.StatusNoContent)
+	expectStatus(t, do(t, server, "PROPFIND", "/dav/dir/", "", map[string]string{"Depth": "0"}), http.StatusNotFound)
+	expectStatus(t, do(t, server, "GET", "/dav/dir/b.txt", "", nil), http.StatusNotFound)
+	expectStatus(t, do(t, server, "DELETE", "/dav/dir", "", nil), http.StatusNotFound)
+}
+
+const lockBody = `<?xml version="1.0" encoding="utf-8"?>
+<D:lockinfo xmlns:D="DAV:"><D:lockscope><D:exclusive/></D:lockscope><D:locktype><D:write/></D:locktype></D:lockinfo>`
+
+func TestLockConflict(t *testing.T) {
+	_, server := newTestServer(t)
+
+	resp := do(t, server, "LOCK", "/dav/a.txt", lockBody, map[string]string{"Depth": "0"})
+	expectStatus(t, resp, http.StatusOK)
+	token := resp.Header.Get("Lock-Token")
+	if token == "" {
+		t.Fatal("missing Lock-Token")
+	}
+
+	expectStatus(t, do(t, server, "LOCK", "/dav/a.txt", lockBody, map[string]string{"Depth": "0"}), http.StatusLocked)
+	expectStatus(t, do(t, server, "PUT", "/dav/a.txt", "x", nil), http.StatusLocked)
+	expectStatus(t, do(t, server, "DELETE", "/dav/a.txt", "", nil), http.StatusLocked)
+	expectStatus(t, do(t, server, "PUT", "/dav/a.txt", "x", map[string]string{"If": "(" + token + ")"}), http.StatusNoContent)
+
+	expectStatus(t, do(t, server, "UNLOCK", "/dav/a.txt", "", map[string]string{"Lock-Token": "<opaquelocktoken:bad>"}), http.StatusConflict)
+	expectStatus(t, do(t, server, "UNLOCK", "/dav/a.txt", "", map[string]string{"Lock-Token": token}), http.StatusNoContent)
+	expectStatus(t, do(t, server, "PUT", "/dav/a.txt", "y", nil), http.StatusNoContent)
+}
+
+func TestLockRefresh(t *testing.T) {
+	_, server := newTestServer(t)
+
+	resp := do(t, server, "LOCK", "/dav/a.txt", lockBody, map[string]string{"Depth": "0", "Timeout": "Second-60"})
+	expectStatus(t, resp, http.StatusOK)
+	token := resp.Header.Get("Lock-Token")
+
+	// 空请求体加 If 头刷新锁
+	resp = do(t, server, "LOCK", "/dav/a.txt", "", map[string]string{"If": "(" + token + ")", "Timeout": "Second-120"})
+	expectStatus(t, resp, http.StatusOK)
+	if body := readBody(t, resp); !strings.Contains(body, "Second-120") {
+		t.Fatalf("timeout not refreshed:\n%v", body)
+	}
+
+	// Infinite 按最长有效期处理
+	resp = do(t, server, "LOCK", "/dav/a.txt", "", map[string]string{"If": "(" + token + ")", "Timeout": "Infinite"})
+	expectStatus(t, resp, http.StatusOK)
+	if body := readBody(t, resp); !strings.Contains(body, "Second-86400") {
+		t.Fatalf("infinite timeout not capped:\n%v", body)
+	}
+
+	// 锁不覆盖请求的路径
+	resp = do(t, server, "LOCK", "/dav/dir/b.txt", "", map[string]string{"If": "(" + token + ")"})
+	expectStatus(t, resp, http.StatusPreconditionFailed)
+	expectStatus(t, do(t, server, "LOCK", "/dav/a.txt", "", map[string]string{"If": "(<opaquelocktoken:bad>)"}), http.StatusPreconditionFailed)
+}
+
+func TestLockDepthInfinity(t *testing.T) {
+	_, server := newTestServer(t)
+
+	resp := do(t, server, "LOCK", "/dav/dir", lockBody, map[string]string{"Depth": "infinity"})
+	expectStatus(t, resp, http.StatusOK)
+	expectStatus(t, do(t, server, "PUT", "/dav/dir/b.txt", "x", nil), http.StatusLocked)
+	expectStatus(t, do(t, server, "LOCK", "/dav/dir/b.txt", lockBody, map[string]string{"Depth": "0"}), http.StatusLocked)
+	expectStatus(t, do(t, server, "PUT", "/dav/a.txt", "x", nil), http.StatusNoContent)
+}
+
+func TestPathCache(t *testing.T) {
+	d, server := newTestServer(t)
+
+	expectStatus(t, do(t, server, "GET", "/dav/dir/b.txt", "", nil), http.StatusOK)
+	lists := d.count("/adrive/v3/file/list")
+
+	// 路径已缓存，不需要再从根目录逐级列出
+	expectStatus(t, do(t, server, "GET", "/dav/dir/b.txt", "", nil), http.StatusOK)
+	expectStatus(t, do(t, server, "PROPFIND", "/dav/dir/b.txt", "", map[string]string{"Depth": "0"}), http.StatusMultiStatus)
+	if n := d.count("/adrive/v3/file/list"); n != lists {
+		t.Fatalf("got %v list requests after cache warmed, want %v", n, lists)
+	}
+
+	// 写入后缓存失效，读取到新的内容
+	expectStatus(t, do(t, server, "PUT", "/dav/dir/b.txt", "new", nil), http.StatusNoContent)
+	resp := do(t, server, "GET", "/dav/dir/b.txt", "", nil)
+	expectStatus(t, resp, http.StatusOK)
+	if body := readBody(t, resp); body != "new" {
+		t.Fatalf("got %q, want %q", body, "new")
+	}
+}
@@ -0,0 +1,124 @@
+package webdav
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type propfind struct {
+	XMLName  xml.Name  `xml:"DAV: propfind"`
+	AllProp  *struct{} `xml:"DAV: allprop"`
+	PropName *struct{} `xml:"DAV: propname"`
+	Prop     *propList `xml:"DAV: prop"`
+}
+
+type propList struct {
+	Names []struct {
+		XMLName xml.Name
+	} `xml:",any"`
+}
+
+type propertyupdate struct {
+	XMLName xml.Name `xml:"DAV: propertyupdate"`
+	Set     []struct {
+		Prop propList `xml:"DAV: prop"`
+	} `xml:"DAV: set"`
+	Remove []struct {
+		Prop propList `xml:"DAV: prop"`
+	} `xml:"DAV: remove"`
+}
+
+type lockinfo struct {
+	XMLName   xml.Name  `xml:"DAV: lockinfo"`
+	Exclusive *struct{} `xml:"DAV: lockscope>exclusive"`
+	Shared    *struct{} `xml:"DAV: lockscope>shared"`
+	Write     *struct{} `xml:"DAV: locktype>write"`
+	Owner     struct {
+		InnerXML string `xml:",innerxml"`
+	} `xml:"DAV: owner"`
+}
+
+type property struct {
+	XMLName  xml.Name
+	InnerXML string `xml:",innerxml"`
+}
+
+type propstat struct {
+	Props  []property `xml:"D:prop>_ignored_"`
+	Status string     `xml:"D:status"`
+}
+
+type response struct {
+	Href     string     `xml:"D:href"`
+	Propstat []propstat `xml:"D:propstat"`
+}
+
+type multistatus struct {
+	XMLName   xml.Name   `xml:"D:multistatus"`
+	XmlnsD    string     `xml:"xmlns:D,attr"`
+	Responses []response `xml:"D:response"`
+}
+
+func davName(local string) xml.Name {
+	return xml.Name{Space: "DAV:", Local: local}
+}
+
+func statusText(code int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", code, http.StatusText(code))
+}
+
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+func writeMultistatus(w http.ResponseWriter, responses []response) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	io.WriteString(w, xml.Header)
+	xml.NewEncoder(w).Encode(&multistatus{XmlnsD: "DAV:", Responses: responses})
+}
+
+// writeError 返回带有 RFC 4918 前置条件元素的错误响应
+func writeError(w http.ResponseWriter, status int, condition string) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(status)
+	io.WriteString(w, xml.Header)
+	fmt.Fprintf(w, `<D:error xmlns:D="DAV:"><D:%s/></D:error>`, condition)
+}
+
+func activeLockXML(l *lock, href string) string {
+	depth := "0"
+	if l.infinite {
+		depth = "infinity"
+	}
+	timeout := "Infinite"
+	if l.timeout > 0 {
+		timeout = fmt.Sprintf("Second-%d", l.timeout/time.Second)
+	}
+
+	var b strings.Builder
+	b.WriteString(`<D:activelock xmlns:D="DAV:">`)
+	b.WriteString(`<D:locktype><D:write/></D:locktype>`)
+	b.WriteString(`<D:lockscope><D:exclusive/></D:lockscope>`)
+	fmt.Fprintf(&b, `<D:depth>%s</D:depth>`, depth)
+	if l.owner != "" {
+		fmt.Fprintf(&b, `<D:owner>%s</D:owner>`, l.owner)
+	}
+	fmt.Fprintf(&b, `<D:timeout>%s</D:timeout>`, timeout)
+	fmt.Fprintf(&b, `<D:locktoken><D:href>%s</D:href></D:locktoken>`, escapeXML(l.token))
+	fmt.Fprintf(&b, `<D:lockroot><D:href>%s</D:href></D:lockroot>`, escapeXML(href))
+	b.WriteString(`</D:activelock>`)
+	return b.String()
+}
+
+const supportedLockXML = `<D:lockentry xmlns:D="DAV:">` +
+	`<D:lockscope><D:exclusive/></D:lockscope>` +
+	`<D:locktype><D:write/></D:locktype>` +
+	`</D:lockentry>`